@@ -1,21 +1,32 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"ha-monitor/internal/action"
+	"ha-monitor/internal/admin"
+	"ha-monitor/internal/checker"
 	"ha-monitor/internal/config"
 	"ha-monitor/internal/monitor"
+	"ha-monitor/internal/mqtt"
+	"ha-monitor/internal/notify"
+	"ha-monitor/internal/scheduler"
+	"ha-monitor/internal/store"
 	"ha-monitor/internal/tuya"
-
-	"github.com/robfig/cron/v3"
 )
 
 func main() {
 	configPath := flag.String("config", "config/config.yaml", "path to config file")
+	validate := flag.Bool("validate", false, "run every target until it passes (or retry-timeout elapses) and exit, without starting the cron scheduler")
+	retryTimeout := flag.Duration("retry-timeout", 30*time.Second, "in -validate mode, keep retrying until all targets pass or this duration elapses")
+	sleep := flag.Duration("sleep", 2*time.Second, "in -validate mode, time to sleep between retries")
 	flag.Parse()
 
 	log.Println("Starting HomeAssistant Monitor...")
@@ -26,14 +37,16 @@ func main() {
 	}
 
 	cfg := loader.Get()
-	mon := monitor.NewMonitor(
-		cfg.Monitor.HAURL,
-		cfg.Monitor.HAToken,
-		monitor.NotifyConfig{
-			APIURL:   cfg.Monitor.Notify.APIURL,
-			APIToken: cfg.Monitor.Notify.APIToken,
-			TopicID:  cfg.Monitor.Notify.TopicID,
-		},
+
+	st, err := toStore(cfg.Monitor.Store)
+	if err != nil {
+		log.Fatalf("Failed to build store: %v", err)
+	}
+	defer st.Close()
+
+	mon, err := monitor.NewMonitor(
+		toTargets(cfg.Monitor.Targets),
+		toNotifyChannels(cfg.Monitor.Notify.Channels),
 		tuya.Config{
 			Enabled:     cfg.Monitor.Tuya.Enabled,
 			AccessID:    cfg.Monitor.Tuya.AccessID,
@@ -42,24 +55,42 @@ func main() {
 			Region:      cfg.Monitor.Tuya.Region,
 			WaitSeconds: cfg.Monitor.Tuya.WaitSeconds,
 		},
-		cfg.Monitor.RetryTimes,
+		toMQTTConfig(cfg.Monitor.MQTT),
+		toActions(cfg.Monitor.Actions),
+		st,
 		cfg.Monitor.Timeout,
 	)
+	if err != nil {
+		log.Fatalf("Failed to build monitor: %v", err)
+	}
 
-	// 创建一个支持秒级调度的cron调度器
-	// 注意：配置虽然支持热加载，但schedule字段的更改并不会更新cron调度器
-	c := cron.New(cron.WithSeconds())
+	// --validate mirrors `goss validate --retry-timeout --sleep`: run until
+	// every target passes or the deadline elapses, then exit. Useful for
+	// confirming recovery right after a restart instead of waiting for the
+	// next cron tick.
+	if *validate {
+		if !mon.Validate(*retryTimeout, *sleep) {
+			os.Exit(1)
+		}
+		return
+	}
 
-	if _, err := c.AddFunc(cfg.Monitor.Schedule, func() {
-		currentCfg := loader.Get()
-		mon.UpdateConfig(
-			currentCfg.Monitor.HAURL,
-			currentCfg.Monitor.HAToken,
-			monitor.NotifyConfig{
-				APIURL:   currentCfg.Monitor.Notify.APIURL,
-				APIToken: currentCfg.Monitor.Notify.APIToken,
-				TopicID:  currentCfg.Monitor.Notify.TopicID,
-			},
+	sched := scheduler.New()
+	if err := sched.Start(cfg.Monitor.Schedule, func() {
+		if err := mon.Check(); err != nil {
+			log.Printf("Monitor check failed: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("Failed to add cron job: %v", err)
+	}
+
+	// On a config reload, apply the updated monitor settings and reschedule
+	// the cron job if its spec changed - previously this package only ever
+	// picked up schedule changes on a restart.
+	loader.OnChange(func(currentCfg *config.Config) {
+		if err := mon.UpdateConfig(
+			toTargets(currentCfg.Monitor.Targets),
+			toNotifyChannels(currentCfg.Monitor.Notify.Channels),
 			tuya.Config{
 				Enabled:     currentCfg.Monitor.Tuya.Enabled,
 				AccessID:    currentCfg.Monitor.Tuya.AccessID,
@@ -68,18 +99,25 @@ func main() {
 				Region:      currentCfg.Monitor.Tuya.Region,
 				WaitSeconds: currentCfg.Monitor.Tuya.WaitSeconds,
 			},
-			currentCfg.Monitor.RetryTimes,
+			toActions(currentCfg.Monitor.Actions),
 			currentCfg.Monitor.Timeout,
-		)
+		); err != nil {
+			log.Printf("Failed to apply updated config: %v", err)
+			return
+		}
 
-		if err := mon.Check(); err != nil {
-			log.Printf("Monitor check failed: %v", err)
+		if err := sched.Reschedule(currentCfg.Monitor.Schedule); err != nil {
+			log.Printf("Failed to reschedule cron job: %v", err)
 		}
-	}); err != nil {
-		log.Fatalf("Failed to add cron job: %v", err)
-	}
+	})
 
-	c.Start()
+	adminServer := admin.New(admin.Config{
+		Enabled:      cfg.Monitor.Admin.Enabled,
+		Addr:         cfg.Monitor.Admin.Addr,
+		Token:        cfg.Monitor.Admin.Token,
+		ConfirmToken: cfg.Monitor.Admin.ConfirmToken,
+	}, mon)
+	adminServer.Start()
 
 	// 优雅关闭
 	sigChan := make(chan os.Signal, 1)
@@ -88,5 +126,189 @@ func main() {
 	<-sigChan
 	log.Println("Shutting down gracefully...")
 
-	c.Stop()
+	sched.Stop()
+	mon.Close()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := adminServer.Stop(shutdownCtx); err != nil {
+		log.Printf("Failed to stop admin API server: %v", err)
+	}
+}
+
+func toMQTTConfig(cfg config.MQTTConfig) mqtt.Config {
+	return mqtt.Config{
+		Enabled:         cfg.Enabled,
+		BrokerURL:       cfg.BrokerURL,
+		ClientID:        cfg.ClientID,
+		Username:        cfg.Username,
+		Password:        cfg.Password,
+		StatusTopic:     cfg.StatusTopic,
+		HeartbeatWindow: time.Duration(cfg.HeartbeatWindowSeconds) * time.Second,
+	}
+}
+
+// toStore builds the configured state-persistence backend. An empty backend
+// (or "memory") selects the in-memory store, matching the monitor's original
+// (pre-persistence) behavior; any other unrecognized backend is an error.
+func toStore(cfg config.StoreConfig) (store.Store, error) {
+	switch cfg.Backend {
+	case "", "memory":
+		return store.NewMemoryStore(), nil
+	case "boltdb":
+		return store.NewBoltStore(cfg.BoltDB.Path)
+	case "redis":
+		return store.NewRedisStore(store.RedisConfig{
+			Addr:         cfg.Redis.Addr,
+			Password:     cfg.Redis.Password,
+			DB:           cfg.Redis.DB,
+			MaxIdle:      cfg.Redis.MaxIdle,
+			MaxActive:    cfg.Redis.MaxActive,
+			IdleTimeout:  time.Duration(cfg.Redis.IdleTimeoutSeconds) * time.Second,
+			DialTimeout:  time.Duration(cfg.Redis.DialTimeoutSeconds) * time.Second,
+			ReadTimeout:  time.Duration(cfg.Redis.ReadTimeoutSeconds) * time.Second,
+			WriteTimeout: time.Duration(cfg.Redis.WriteTimeoutSeconds) * time.Second,
+			KeyPrefix:    cfg.Redis.KeyPrefix,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown store backend %q", cfg.Backend)
+	}
+}
+
+func toTargets(targets []config.TargetConfig) []checker.TargetConfig {
+	out := make([]checker.TargetConfig, len(targets))
+	for i, t := range targets {
+		onFailure := make([]checker.OnFailureAction, len(t.OnFailure))
+		for j, a := range t.OnFailure {
+			onFailure[j] = checker.OnFailureAction(a)
+		}
+
+		out[i] = checker.TargetConfig{
+			Name:       t.Name,
+			Type:       checker.TargetType(t.Type),
+			RetryTimes: t.RetryTimes,
+			Interval:   time.Duration(t.Interval) * time.Second,
+			Severity:   checker.Severity(t.Severity),
+			OnFailure:  onFailure,
+			HTTP: checker.HTTPConfig{
+				URL:              t.HTTP.URL,
+				Method:           t.HTTP.Method,
+				Token:            t.HTTP.Token,
+				ExpectedStatus:   t.HTTP.ExpectedStatus,
+				BodyRegex:        t.HTTP.BodyRegex,
+				JSONPath:         t.HTTP.JSONPath,
+				JSONPathValue:    t.HTTP.JSONPathValue,
+				LatencyThreshold: time.Duration(t.HTTP.LatencyThresholdMS) * time.Millisecond,
+				Headers:          t.HTTP.Headers,
+				Timeout:          time.Duration(t.HTTP.TimeoutSeconds) * time.Second,
+			},
+			TCP: checker.TCPConfig{
+				Address: t.TCP.Address,
+				Timeout: time.Duration(t.TCP.TimeoutSeconds) * time.Second,
+			},
+			Process: checker.ProcessConfig{
+				PID:  t.Process.PID,
+				Name: t.Process.Name,
+			},
+			DNS: checker.DNSConfig{
+				Host:       t.DNS.Host,
+				ExpectedIP: t.DNS.ExpectedIP,
+				Timeout:    time.Duration(t.DNS.TimeoutSeconds) * time.Second,
+			},
+			Command: checker.CommandConfig{
+				Command:      t.Command.Command,
+				Args:         t.Command.Args,
+				ExpectedCode: t.Command.ExpectedCode,
+				Timeout:      time.Duration(t.Command.TimeoutSeconds) * time.Second,
+			},
+		}
+	}
+	return out
+}
+
+func toNotifyChannels(channels []config.ChannelConfig) []notify.ChannelConfig {
+	out := make([]notify.ChannelConfig, len(channels))
+	for i, ch := range channels {
+		out[i] = notify.ChannelConfig{
+			Name:    ch.Name,
+			Type:    ch.Type,
+			Enabled: ch.Enabled,
+			Bark: notify.BarkConfig{
+				ServerURL: ch.Bark.ServerURL,
+				DeviceKey: ch.Bark.DeviceKey,
+			},
+			Telegram: notify.TelegramConfig{
+				BotToken: ch.Telegram.BotToken,
+				ChatID:   ch.Telegram.ChatID,
+			},
+			Discord: notify.DiscordConfig{
+				WebhookURL: ch.Discord.WebhookURL,
+			},
+			Ntfy: notify.NtfyConfig{
+				ServerURL: ch.Ntfy.ServerURL,
+				Topic:     ch.Ntfy.Topic,
+				Priority:  ch.Ntfy.Priority,
+			},
+			Email: notify.EmailConfig{
+				SMTPHost: ch.Email.SMTPHost,
+				SMTPPort: ch.Email.SMTPPort,
+				Username: ch.Email.Username,
+				Password: ch.Email.Password,
+				From:     ch.Email.From,
+				To:       ch.Email.To,
+			},
+			Webhook: notify.WebhookConfig{
+				URL:          ch.Webhook.URL,
+				Method:       ch.Webhook.Method,
+				Headers:      ch.Webhook.Headers,
+				BodyTemplate: ch.Webhook.BodyTemplate,
+			},
+		}
+	}
+	return out
+}
+
+func toActions(actions []config.ActionConfig) []action.Config {
+	out := make([]action.Config, len(actions))
+	for i, a := range actions {
+		out[i] = action.Config{
+			Name:            a.Name,
+			Type:            action.Type(a.Type),
+			RetryTimes:      a.RetryTimes,
+			CooldownSeconds: a.CooldownSeconds,
+			Shell: action.ShellConfig{
+				Command: a.Shell.Command,
+				Args:    a.Shell.Args,
+				Timeout: time.Duration(a.Shell.TimeoutSeconds) * time.Second,
+			},
+			SSH: action.SSHConfig{
+				Host:           a.SSH.Host,
+				Port:           a.SSH.Port,
+				User:           a.SSH.User,
+				PrivateKeyPath: a.SSH.PrivateKeyPath,
+				Command:        a.SSH.Command,
+				Timeout:        time.Duration(a.SSH.TimeoutSeconds) * time.Second,
+			},
+			DockerRestart: action.DockerRestartConfig{
+				SocketPath: a.DockerRestart.SocketPath,
+				Container:  a.DockerRestart.Container,
+				Timeout:    time.Duration(a.DockerRestart.TimeoutSeconds) * time.Second,
+			},
+			SystemdRestart: action.SystemdRestartConfig{
+				Unit:    a.SystemdRestart.Unit,
+				Timeout: time.Duration(a.SystemdRestart.TimeoutSeconds) * time.Second,
+			},
+			WakeOnLAN: action.WakeOnLANConfig{
+				MAC:       a.WakeOnLAN.MAC,
+				Broadcast: a.WakeOnLAN.Broadcast,
+			},
+			Webhook: action.WebhookConfig{
+				URL:     a.Webhook.URL,
+				Method:  a.Webhook.Method,
+				Headers: a.Webhook.Headers,
+				Timeout: time.Duration(a.Webhook.TimeoutSeconds) * time.Second,
+			},
+		}
+	}
+	return out
 }
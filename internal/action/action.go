@@ -0,0 +1,85 @@
+// Package action defines the pluggable "recovery action" abstraction the
+// monitor runs when a target's health check keeps failing. Power-cycling a
+// Tuya smart plug is just one such action; this package also provides
+// remote-command, container, service, and wake-on-LAN variants so the
+// monitor isn't tied to a single "smart plug" remediation story.
+package action
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ha-monitor/internal/tuya"
+)
+
+// Action is a single configured remediation step.
+type Action interface {
+	// Name identifies this action, as referenced from a target's on_failure
+	// list.
+	Name() string
+	// Execute performs the remediation. reason explains why it was
+	// triggered, e.g. which target failed and its last error.
+	Execute(ctx context.Context, reason string) error
+	// Cooldown is the minimum time to wait between two runs of this action.
+	Cooldown() time.Duration
+}
+
+type Type string
+
+const (
+	TypeTuyaSwitch     Type = "tuya-switch"
+	TypeShell          Type = "shell"
+	TypeSSH            Type = "ssh"
+	TypeDockerRestart  Type = "docker-restart"
+	TypeSystemdRestart Type = "systemd-restart"
+	TypeWakeOnLAN      Type = "wol"
+	TypeWebhook        Type = "webhook"
+)
+
+// Config describes one configured recovery action. Only the block matching
+// Type needs to be populated.
+type Config struct {
+	Name            string
+	Type            Type
+	RetryTimes      int
+	CooldownSeconds int
+
+	Shell          ShellConfig
+	SSH            SSHConfig
+	DockerRestart  DockerRestartConfig
+	SystemdRestart SystemdRestartConfig
+	WakeOnLAN      WakeOnLANConfig
+	Webhook        WebhookConfig
+}
+
+// New builds the concrete Action for a config. tuyaClient is shared by any
+// tuya-switch actions: the monitor only ever holds one Tuya client, matching
+// the single access_id/access_key/device_id block under monitor.tuya.
+func New(cfg Config, tuyaClient *tuya.Client, httpClient *http.Client) (Action, error) {
+	cooldown := time.Duration(cfg.CooldownSeconds) * time.Second
+
+	switch cfg.Type {
+	case TypeTuyaSwitch:
+		return newTuyaSwitchAction(cfg.Name, cooldown, tuyaClient), nil
+	case TypeShell:
+		return newShellAction(cfg.Name, cooldown, cfg.Shell), nil
+	case TypeSSH:
+		return newSSHAction(cfg.Name, cooldown, cfg.SSH), nil
+	case TypeDockerRestart:
+		return newDockerRestartAction(cfg.Name, cooldown, cfg.DockerRestart), nil
+	case TypeSystemdRestart:
+		return newSystemdRestartAction(cfg.Name, cooldown, cfg.SystemdRestart), nil
+	case TypeWakeOnLAN:
+		return newWakeOnLANAction(cfg.Name, cooldown, cfg.WakeOnLAN), nil
+	case TypeWebhook:
+		return newWebhookAction(cfg.Name, cooldown, cfg.Webhook, httpClient), nil
+	default:
+		return nil, fmt.Errorf("unknown action type: %q", cfg.Type)
+	}
+}
+
+func isSuccessStatus(code int) bool {
+	return code >= 200 && code < 300
+}
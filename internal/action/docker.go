@@ -0,0 +1,76 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+const defaultDockerSocket = "/var/run/docker.sock"
+
+// DockerRestartConfig configures restarting a container through the Docker
+// Engine API over its local unix socket.
+type DockerRestartConfig struct {
+	SocketPath string // defaults to /var/run/docker.sock
+	Container  string // container name or ID
+	Timeout    time.Duration
+}
+
+type dockerRestartAction struct {
+	name     string
+	cooldown time.Duration
+	cfg      DockerRestartConfig
+	client   *http.Client
+}
+
+func newDockerRestartAction(name string, cooldown time.Duration, cfg DockerRestartConfig) *dockerRestartAction {
+	socketPath := cfg.SocketPath
+	if socketPath == "" {
+		socketPath = defaultDockerSocket
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+
+	return &dockerRestartAction{name: name, cooldown: cooldown, cfg: cfg, client: client}
+}
+
+func (a *dockerRestartAction) Name() string { return a.name }
+
+func (a *dockerRestartAction) Cooldown() time.Duration { return a.cooldown }
+
+func (a *dockerRestartAction) Execute(ctx context.Context, reason string) error {
+	timeout := a.cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultShellTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Host and scheme are ignored by the unix-socket dialer above; they just
+	// need to be well-formed.
+	url := fmt.Sprintf("http://unix/containers/%s/restart", a.cfg.Container)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("restart container %q: %w", a.cfg.Container, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("docker API returned status %d restarting container %q", resp.StatusCode, a.cfg.Container)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+const defaultShellTimeout = 30 * time.Second
+
+// ShellConfig configures a local command run as a recovery action.
+type ShellConfig struct {
+	Command string
+	Args    []string
+	Timeout time.Duration
+}
+
+type shellAction struct {
+	name     string
+	cooldown time.Duration
+	cfg      ShellConfig
+}
+
+func newShellAction(name string, cooldown time.Duration, cfg ShellConfig) *shellAction {
+	return &shellAction{name: name, cooldown: cooldown, cfg: cfg}
+}
+
+func (a *shellAction) Name() string { return a.name }
+
+func (a *shellAction) Cooldown() time.Duration { return a.cooldown }
+
+func (a *shellAction) Execute(ctx context.Context, reason string) error {
+	if a.cfg.Command == "" {
+		return nil
+	}
+
+	timeout := a.cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultShellTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, a.cfg.Command, a.cfg.Args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("run shell command: %w (output: %s)", err, output)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHConfig configures an arbitrary remediation command run on a remote host
+// over SSH with key-based auth.
+type SSHConfig struct {
+	Host           string
+	Port           int
+	User           string
+	PrivateKeyPath string
+	Command        string
+	Timeout        time.Duration
+}
+
+type sshAction struct {
+	name     string
+	cooldown time.Duration
+	cfg      SSHConfig
+}
+
+func newSSHAction(name string, cooldown time.Duration, cfg SSHConfig) *sshAction {
+	return &sshAction{name: name, cooldown: cooldown, cfg: cfg}
+}
+
+func (a *sshAction) Name() string { return a.name }
+
+func (a *sshAction) Cooldown() time.Duration { return a.cooldown }
+
+func (a *sshAction) Execute(ctx context.Context, reason string) error {
+	key, err := os.ReadFile(a.cfg.PrivateKeyPath)
+	if err != nil {
+		return fmt.Errorf("read private key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("parse private key: %w", err)
+	}
+
+	port := a.cfg.Port
+	if port <= 0 {
+		port = 22
+	}
+	timeout := a.cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultShellTimeout
+	}
+
+	config := &ssh.ClientConfig{
+		User: a.cfg.User,
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		// This targets a handful of devices the operator already controls
+		// (routers, NAS boxes, home servers), not arbitrary hosts, so we
+		// don't maintain a known_hosts file for them.
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         timeout,
+	}
+
+	addr := fmt.Sprintf("%s:%d", a.cfg.Host, port)
+	client, err := ssh.Dial("tcp", addr, config)
+	if err != nil {
+		return fmt.Errorf("dial %s: %w", addr, err)
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("open session: %w", err)
+	}
+	defer session.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		output []byte
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		output, err := session.CombinedOutput(a.cfg.Command)
+		done <- result{output: output, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return fmt.Errorf("run command over ssh: %w (output: %s)", r.err, r.output)
+		}
+		return nil
+	case <-ctx.Done():
+		// Closing the session/client unblocks the CombinedOutput goroutine,
+		// which otherwise has no deadline of its own.
+		session.Close()
+		client.Close()
+		return fmt.Errorf("run command over ssh: %w", ctx.Err())
+	}
+}
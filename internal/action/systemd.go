@@ -0,0 +1,59 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+)
+
+// SystemdRestartConfig configures restarting a systemd unit over D-Bus.
+type SystemdRestartConfig struct {
+	Unit    string
+	Timeout time.Duration
+}
+
+type systemdRestartAction struct {
+	name     string
+	cooldown time.Duration
+	cfg      SystemdRestartConfig
+}
+
+func newSystemdRestartAction(name string, cooldown time.Duration, cfg SystemdRestartConfig) *systemdRestartAction {
+	return &systemdRestartAction{name: name, cooldown: cooldown, cfg: cfg}
+}
+
+func (a *systemdRestartAction) Name() string { return a.name }
+
+func (a *systemdRestartAction) Cooldown() time.Duration { return a.cooldown }
+
+func (a *systemdRestartAction) Execute(ctx context.Context, reason string) error {
+	timeout := a.cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultShellTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := dbus.NewSystemConnectionContext(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to systemd over dbus: %w", err)
+	}
+	defer conn.Close()
+
+	done := make(chan string, 1)
+	if _, err := conn.RestartUnitContext(ctx, a.cfg.Unit, "replace", done); err != nil {
+		return fmt.Errorf("restart unit %q: %w", a.cfg.Unit, err)
+	}
+
+	select {
+	case result := <-done:
+		if result != "done" {
+			return fmt.Errorf("restart unit %q finished with result %q", a.cfg.Unit, result)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("restart unit %q: %w", a.cfg.Unit, ctx.Err())
+	}
+}
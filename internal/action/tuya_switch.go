@@ -0,0 +1,40 @@
+package action
+
+import (
+	"context"
+	"time"
+
+	"ha-monitor/internal/tuya"
+)
+
+// TuyaSwitchAction power-cycles a Tuya smart plug, e.g. to cold-reboot a
+// router or access point plugged into it.
+type TuyaSwitchAction struct {
+	name     string
+	cooldown time.Duration
+	client   *tuya.Client
+}
+
+func newTuyaSwitchAction(name string, cooldown time.Duration, client *tuya.Client) *TuyaSwitchAction {
+	return &TuyaSwitchAction{name: name, cooldown: cooldown, client: client}
+}
+
+func (a *TuyaSwitchAction) Name() string { return a.name }
+
+func (a *TuyaSwitchAction) Cooldown() time.Duration { return a.cooldown }
+
+func (a *TuyaSwitchAction) Execute(ctx context.Context, reason string) error {
+	return a.client.RestartDevice()
+}
+
+// LoadToken seeds the underlying Tuya client's token cache, e.g. from the
+// persistent store, so a restart doesn't force a fresh /v1.0/token call.
+func (a *TuyaSwitchAction) LoadToken(snap tuya.TokenSnapshot) {
+	a.client.LoadToken(snap)
+}
+
+// SnapshotToken returns the underlying Tuya client's current cached token,
+// for persistence.
+func (a *TuyaSwitchAction) SnapshotToken() (tuya.TokenSnapshot, bool) {
+	return a.client.SnapshotToken()
+}
@@ -0,0 +1,69 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WebhookConfig configures a generic HTTP call as a recovery action, e.g. to
+// trigger an external automation (a Home Assistant script, an n8n flow).
+type WebhookConfig struct {
+	URL     string
+	Method  string // defaults to POST
+	Headers map[string]string
+	Timeout time.Duration
+}
+
+type webhookAction struct {
+	name     string
+	cooldown time.Duration
+	cfg      WebhookConfig
+	client   *http.Client
+}
+
+func newWebhookAction(name string, cooldown time.Duration, cfg WebhookConfig, client *http.Client) *webhookAction {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &webhookAction{name: name, cooldown: cooldown, cfg: cfg, client: client}
+}
+
+func (a *webhookAction) Name() string { return a.name }
+
+func (a *webhookAction) Cooldown() time.Duration { return a.cooldown }
+
+func (a *webhookAction) Execute(ctx context.Context, reason string) error {
+	timeout := a.cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	method := a.cfg.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, a.cfg.URL, strings.NewReader(reason))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	for k, v := range a.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !isSuccessStatus(resp.StatusCode) {
+		return fmt.Errorf("webhook returned status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+const defaultWOLBroadcast = "255.255.255.255:9"
+
+// WakeOnLANConfig configures a Wake-on-LAN magic packet send.
+type WakeOnLANConfig struct {
+	MAC       string // e.g. "aa:bb:cc:dd:ee:ff"
+	Broadcast string // e.g. "255.255.255.255:9"
+}
+
+type wakeOnLANAction struct {
+	name     string
+	cooldown time.Duration
+	cfg      WakeOnLANConfig
+}
+
+func newWakeOnLANAction(name string, cooldown time.Duration, cfg WakeOnLANConfig) *wakeOnLANAction {
+	return &wakeOnLANAction{name: name, cooldown: cooldown, cfg: cfg}
+}
+
+func (a *wakeOnLANAction) Name() string { return a.name }
+
+func (a *wakeOnLANAction) Cooldown() time.Duration { return a.cooldown }
+
+func (a *wakeOnLANAction) Execute(ctx context.Context, reason string) error {
+	packet, err := magicPacket(a.cfg.MAC)
+	if err != nil {
+		return err
+	}
+
+	broadcast := a.cfg.Broadcast
+	if broadcast == "" {
+		broadcast = defaultWOLBroadcast
+	}
+
+	addr, err := net.ResolveUDPAddr("udp", broadcast)
+	if err != nil {
+		return fmt.Errorf("resolve broadcast address %q: %w", broadcast, err)
+	}
+
+	conn, err := net.DialUDP("udp", nil, addr)
+	if err != nil {
+		return fmt.Errorf("dial %s: %w", broadcast, err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(packet); err != nil {
+		return fmt.Errorf("send magic packet: %w", err)
+	}
+	return nil
+}
+
+// magicPacket builds the standard Wake-on-LAN payload: 6 bytes of 0xFF
+// followed by the target MAC address repeated 16 times.
+func magicPacket(mac string) ([]byte, error) {
+	hwAddr, err := net.ParseMAC(mac)
+	if err != nil {
+		return nil, fmt.Errorf("parse MAC %q: %w", mac, err)
+	}
+
+	packet := make([]byte, 0, 6+16*len(hwAddr))
+	for i := 0; i < 6; i++ {
+		packet = append(packet, 0xFF)
+	}
+	for i := 0; i < 16; i++ {
+		packet = append(packet, hwAddr...)
+	}
+	return packet, nil
+}
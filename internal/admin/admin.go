@@ -0,0 +1,168 @@
+// Package admin exposes a small HTTP API for operating a running monitor:
+// health/status introspection, triggering an ad-hoc check, a manual Tuya
+// restart, and a Prometheus metrics endpoint.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"ha-monitor/internal/monitor"
+)
+
+// Config configures the admin HTTP server. It is disabled by default.
+type Config struct {
+	Enabled bool
+	Addr    string
+	// Token is required as a bearer token on every endpoint except /healthz.
+	Token string
+	// ConfirmToken is required, in addition to Token, by POST /tuya/restart,
+	// so a leaked or reused Token alone can't trigger a power-cycle.
+	ConfirmToken string
+}
+
+// Server is the admin HTTP API for a single *monitor.Monitor.
+type Server struct {
+	cfg    Config
+	mon    *monitor.Monitor
+	server *http.Server
+}
+
+func New(cfg Config, mon *monitor.Monitor) *Server {
+	s := &Server{cfg: cfg, mon: mon}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/status", s.auth(s.handleStatus))
+	mux.HandleFunc("/check", s.auth(s.handleCheck))
+	mux.HandleFunc("/tuya/restart", s.auth(s.handleTuyaRestart))
+	mux.HandleFunc("/metrics", s.auth(s.handleMetrics))
+
+	s.server = &http.Server{Addr: cfg.Addr, Handler: mux}
+	return s
+}
+
+// Start runs the admin HTTP server in the background if enabled. It returns
+// immediately; call Stop to shut it down.
+func (s *Server) Start() {
+	if !s.cfg.Enabled {
+		return
+	}
+
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("Admin API server stopped: %v", err)
+		}
+	}()
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// auth wraps next with a bearer-token check. It is a no-op if no token is
+// configured, so the server stays usable on a trusted loopback/LAN without
+// forcing auth setup.
+func (s *Server) auth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.cfg.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.cfg.Token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("ok"))
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.mon.Status()); err != nil {
+		log.Printf("Failed to encode status response: %v", err)
+	}
+}
+
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := s.mon.Check(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write([]byte("ok"))
+}
+
+func (s *Server) handleTuyaRestart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.cfg.ConfirmToken == "" || r.Header.Get("X-Confirm-Token") != s.cfg.ConfirmToken {
+		http.Error(w, "missing or invalid confirm token", http.StatusForbidden)
+		return
+	}
+
+	if err := s.mon.RestartTuya(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write([]byte("ok"))
+}
+
+// handleMetrics renders the monitor's status in Prometheus exposition
+// format.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	status := s.mon.Status()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	fmt.Fprintln(w, "# HELP ha_monitor_target_up Whether the target's last check succeeded (1) or failed (0).")
+	fmt.Fprintln(w, "# TYPE ha_monitor_target_up gauge")
+	for _, t := range status.Targets {
+		fmt.Fprintf(w, "ha_monitor_target_up{target=%q,severity=%q} %d\n", t.Name, t.Severity, boolToInt(t.Healthy))
+	}
+
+	fmt.Fprintln(w, "# HELP ha_monitor_target_fail_count Consecutive failed checks for the target.")
+	fmt.Fprintln(w, "# TYPE ha_monitor_target_fail_count gauge")
+	for _, t := range status.Targets {
+		fmt.Fprintf(w, "ha_monitor_target_fail_count{target=%q,severity=%q} %d\n", t.Name, t.Severity, t.FailCount)
+	}
+
+	fmt.Fprintln(w, "# HELP ha_monitor_target_last_notify_timestamp_seconds Unix time of the last down notification sent for the target.")
+	fmt.Fprintln(w, "# TYPE ha_monitor_target_last_notify_timestamp_seconds gauge")
+	for _, t := range status.Targets {
+		if t.LastNotifyAt.IsZero() {
+			continue
+		}
+		fmt.Fprintf(w, "ha_monitor_target_last_notify_timestamp_seconds{target=%q} %d\n", t.Name, t.LastNotifyAt.Unix())
+	}
+
+	fmt.Fprintln(w, "# HELP ha_monitor_last_action_run_timestamp_seconds Unix time of the last run of a recovery action.")
+	fmt.Fprintln(w, "# TYPE ha_monitor_last_action_run_timestamp_seconds gauge")
+	for _, a := range status.Actions {
+		if a.LastActionRun.IsZero() {
+			continue
+		}
+		fmt.Fprintf(w, "ha_monitor_last_action_run_timestamp_seconds{action=%q} %d\n", a.Name, a.LastActionRun.Unix())
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
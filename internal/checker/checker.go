@@ -0,0 +1,231 @@
+// Package checker implements a multi-target health-check subsystem modeled
+// on goss: each target is a typed resource (http, tcp, process, dns,
+// command) that reports pass/fail, and a Checker can either run every
+// target once or retry until they all pass.
+package checker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type TargetType string
+
+const (
+	TypeHTTP    TargetType = "http"
+	TypeTCP     TargetType = "tcp"
+	TypeProcess TargetType = "process"
+	TypeDNS     TargetType = "dns"
+	TypeCommand TargetType = "command"
+)
+
+type Severity string
+
+const (
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// OnFailureAction is either ActionNotify or the name of an action configured
+// in monitor.actions (see package action).
+type OnFailureAction string
+
+const (
+	ActionNotify OnFailureAction = "notify"
+)
+
+// TargetConfig describes one health-check target. Only the block matching
+// Type needs to be populated.
+type TargetConfig struct {
+	Name       string
+	Type       TargetType
+	RetryTimes int
+	Interval   time.Duration
+	Severity   Severity
+	OnFailure  []OnFailureAction
+
+	HTTP    HTTPConfig
+	TCP     TCPConfig
+	Process ProcessConfig
+	DNS     DNSConfig
+	Command CommandConfig
+}
+
+// Result is the outcome of a single probe of a target.
+type Result struct {
+	Target    string
+	Severity  Severity
+	Success   bool
+	Err       error
+	Latency   time.Duration
+	CheckedAt time.Time
+}
+
+// Target is a single typed health-check resource.
+type Target interface {
+	Name() string
+	Check(ctx context.Context) Result
+}
+
+// New builds the concrete Target for a target config.
+func New(cfg TargetConfig) (Target, error) {
+	switch cfg.Type {
+	case TypeHTTP:
+		return newHTTPTarget(cfg.Name, cfg.Severity, cfg.HTTP), nil
+	case TypeTCP:
+		return newTCPTarget(cfg.Name, cfg.Severity, cfg.TCP), nil
+	case TypeProcess:
+		return newProcessTarget(cfg.Name, cfg.Severity, cfg.Process), nil
+	case TypeDNS:
+		return newDNSTarget(cfg.Name, cfg.Severity, cfg.DNS), nil
+	case TypeCommand:
+		return newCommandTarget(cfg.Name, cfg.Severity, cfg.Command), nil
+	default:
+		return nil, fmt.Errorf("unknown target type: %q", cfg.Type)
+	}
+}
+
+func isSuccessStatus(code int) bool {
+	return code >= 200 && code < 300
+}
+
+// trackedTarget pairs a built Target with the config it came from and the
+// bookkeeping RunOnce needs to honor TargetConfig.Interval: lastChecked and
+// mu are mutated across calls (and possibly across concurrent Check calls
+// from the cron goroutine and the admin API's POST /check), so trackedTarget
+// is always held by pointer, never copied.
+type trackedTarget struct {
+	cfg    TargetConfig
+	target Target
+
+	mu          sync.Mutex
+	lastChecked time.Time
+}
+
+// due reports whether it's time to probe tt again, given its configured
+// Interval, and if so marks it as checked as of now. An Interval of zero
+// means the target has no cadence of its own and is always due, i.e. it's
+// probed on every call, driven solely by monitor.schedule.
+func (tt *trackedTarget) due(now time.Time) bool {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+
+	if tt.cfg.Interval > 0 && now.Sub(tt.lastChecked) < tt.cfg.Interval {
+		return false
+	}
+	tt.lastChecked = now
+	return true
+}
+
+// Checker runs a fixed set of targets, built once from their configs.
+type Checker struct {
+	targets []*trackedTarget
+}
+
+func NewChecker(configs []TargetConfig) (*Checker, error) {
+	tracked := make([]*trackedTarget, 0, len(configs))
+	for _, cfg := range configs {
+		target, err := New(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("target %q: %w", cfg.Name, err)
+		}
+		tracked = append(tracked, &trackedTarget{cfg: cfg, target: target})
+	}
+	return &Checker{targets: tracked}, nil
+}
+
+// TargetConfig returns the config a target was built from, so callers can
+// look up its retry/severity/on_failure settings after a Result comes back.
+func (c *Checker) TargetConfig(name string) (TargetConfig, bool) {
+	for _, tt := range c.targets {
+		if tt.cfg.Name == name {
+			return tt.cfg, true
+		}
+	}
+	return TargetConfig{}, false
+}
+
+// RunOnce probes every target whose Interval has elapsed since it was last
+// checked (or that has no Interval of its own), in parallel, so a slow
+// target can't eat into the ctx deadline the others need to complete their
+// own checks. It's meant to be driven by monitor.schedule: a target with a
+// longer Interval than the schedule simply sits out the ticks it isn't due
+// for.
+func (c *Checker) RunOnce(ctx context.Context) []Result {
+	now := time.Now()
+
+	due := make([]*trackedTarget, 0, len(c.targets))
+	for _, tt := range c.targets {
+		if tt.due(now) {
+			due = append(due, tt)
+		}
+	}
+
+	return c.runAll(ctx, due)
+}
+
+// runAll probes targets in parallel, ignoring Interval. It's unexported
+// since only RunOnce (after filtering by due) and Validate (which always
+// wants every target, regardless of cadence) need it directly.
+func (c *Checker) runAll(ctx context.Context, targets []*trackedTarget) []Result {
+	results := make([]Result, len(targets))
+
+	var wg sync.WaitGroup
+	for i, tt := range targets {
+		wg.Add(1)
+		go func(i int, tt *trackedTarget) {
+			defer wg.Done()
+			results[i] = tt.target.Check(ctx)
+		}(i, tt)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// CheckOne probes a single named target. ok is false if no such target is
+// configured.
+func (c *Checker) CheckOne(ctx context.Context, name string) (Result, bool) {
+	for _, tt := range c.targets {
+		if tt.cfg.Name == name {
+			return tt.target.Check(ctx), true
+		}
+	}
+	return Result{}, false
+}
+
+// Validate re-runs every target, ignoring Interval, until all of them pass
+// or retryTimeout elapses, sleeping for sleep between attempts. This mirrors
+// goss's `goss validate --retry-timeout --sleep` mode and is meant for
+// confirming recovery after a remediation action has run, so it can't afford
+// to sit out a target that isn't "due" yet.
+func (c *Checker) Validate(ctx context.Context, retryTimeout, sleep time.Duration) ([]Result, bool) {
+	deadline := time.Now().Add(retryTimeout)
+
+	for {
+		results := c.runAll(ctx, c.targets)
+		if allPassed(results) {
+			return results, true
+		}
+		if time.Now().After(deadline) {
+			return results, false
+		}
+
+		select {
+		case <-ctx.Done():
+			return results, false
+		case <-time.After(sleep):
+		}
+	}
+}
+
+func allPassed(results []Result) bool {
+	for _, r := range results {
+		if !r.Success {
+			return false
+		}
+	}
+	return true
+}
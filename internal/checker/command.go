@@ -0,0 +1,62 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// CommandConfig checks that running Command exits with ExpectedCode.
+type CommandConfig struct {
+	Command      string
+	Args         []string
+	ExpectedCode int
+	Timeout      time.Duration
+}
+
+type commandTarget struct {
+	name     string
+	severity Severity
+	cfg      CommandConfig
+}
+
+func newCommandTarget(name string, severity Severity, cfg CommandConfig) *commandTarget {
+	return &commandTarget{name: name, severity: severity, cfg: cfg}
+}
+
+func (t *commandTarget) Name() string { return t.name }
+
+func (t *commandTarget) Check(ctx context.Context) Result {
+	start := time.Now()
+	result := Result{Target: t.name, Severity: t.severity, CheckedAt: start}
+
+	timeout := t.cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	cmdCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(cmdCtx, t.cfg.Command, t.cfg.Args...)
+	err := cmd.Run()
+	result.Latency = time.Since(start)
+
+	exitCode := 0
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	} else if err != nil {
+		result.Err = fmt.Errorf("run command: %w", err)
+		return result
+	}
+
+	if exitCode != t.cfg.ExpectedCode {
+		result.Err = fmt.Errorf("command exited with code %d, want %d", exitCode, t.cfg.ExpectedCode)
+		return result
+	}
+
+	result.Success = true
+	return result
+}
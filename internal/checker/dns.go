@@ -0,0 +1,63 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// DNSConfig checks that Host resolves, optionally requiring ExpectedIP to be
+// among the results.
+type DNSConfig struct {
+	Host       string
+	ExpectedIP string
+	Timeout    time.Duration
+}
+
+type dnsTarget struct {
+	name     string
+	severity Severity
+	cfg      DNSConfig
+	resolver *net.Resolver
+}
+
+func newDNSTarget(name string, severity Severity, cfg DNSConfig) *dnsTarget {
+	return &dnsTarget{name: name, severity: severity, cfg: cfg, resolver: net.DefaultResolver}
+}
+
+func (t *dnsTarget) Name() string { return t.name }
+
+func (t *dnsTarget) Check(ctx context.Context) Result {
+	start := time.Now()
+	result := Result{Target: t.name, Severity: t.severity, CheckedAt: start}
+
+	timeout := t.cfg.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ips, err := t.resolver.LookupHost(lookupCtx, t.cfg.Host)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Err = fmt.Errorf("lookup %s: %w", t.cfg.Host, err)
+		return result
+	}
+
+	if t.cfg.ExpectedIP == "" {
+		result.Success = true
+		return result
+	}
+
+	for _, ip := range ips {
+		if ip == t.cfg.ExpectedIP {
+			result.Success = true
+			return result
+		}
+	}
+
+	result.Err = fmt.Errorf("%s resolved to %v, want %s", t.cfg.Host, ips, t.cfg.ExpectedIP)
+	return result
+}
@@ -0,0 +1,132 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// HTTPConfig checks an HTTP(S) endpoint's status code, latency, and
+// optionally its response body.
+type HTTPConfig struct {
+	URL              string
+	Method           string
+	Token            string
+	ExpectedStatus   int // 0 means "any 2xx"
+	BodyRegex        string
+	JSONPath         string
+	JSONPathValue    string // only checked when JSONPath is set
+	LatencyThreshold time.Duration
+	Headers          map[string]string
+	Timeout          time.Duration
+}
+
+type httpTarget struct {
+	name     string
+	severity Severity
+	cfg      HTTPConfig
+	client   *http.Client
+}
+
+func newHTTPTarget(name string, severity Severity, cfg HTTPConfig) *httpTarget {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &httpTarget{
+		name:     name,
+		severity: severity,
+		cfg:      cfg,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (t *httpTarget) Name() string { return t.name }
+
+func (t *httpTarget) Check(ctx context.Context) Result {
+	start := time.Now()
+	result := Result{Target: t.name, Severity: t.severity, CheckedAt: start}
+
+	method := t.cfg.Method
+	if method == "" {
+		method = "GET"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, t.cfg.URL, nil)
+	if err != nil {
+		result.Err = fmt.Errorf("create request: %w", err)
+		return result
+	}
+	if t.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.cfg.Token)
+	}
+	for k, v := range t.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := t.client.Do(req)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+
+	if t.cfg.ExpectedStatus != 0 {
+		if resp.StatusCode != t.cfg.ExpectedStatus {
+			result.Err = fmt.Errorf("unexpected status code: got %d, want %d", resp.StatusCode, t.cfg.ExpectedStatus)
+			return result
+		}
+	} else if !isSuccessStatus(resp.StatusCode) {
+		result.Err = fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return result
+	}
+
+	if t.cfg.LatencyThreshold > 0 && result.Latency > t.cfg.LatencyThreshold {
+		result.Err = fmt.Errorf("latency %s exceeds threshold %s", result.Latency, t.cfg.LatencyThreshold)
+		return result
+	}
+
+	if t.cfg.BodyRegex == "" && t.cfg.JSONPath == "" {
+		result.Success = true
+		return result
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		result.Err = fmt.Errorf("read response body: %w", err)
+		return result
+	}
+
+	if t.cfg.BodyRegex != "" {
+		re, err := regexp.Compile(t.cfg.BodyRegex)
+		if err != nil {
+			result.Err = fmt.Errorf("compile body regex: %w", err)
+			return result
+		}
+		if !re.Match(body) {
+			result.Err = fmt.Errorf("response body did not match %q", t.cfg.BodyRegex)
+			return result
+		}
+	}
+
+	if t.cfg.JSONPath != "" {
+		value := gjson.GetBytes(body, t.cfg.JSONPath)
+		if !value.Exists() {
+			result.Err = fmt.Errorf("json path %q not found in response", t.cfg.JSONPath)
+			return result
+		}
+		if t.cfg.JSONPathValue != "" && value.String() != t.cfg.JSONPathValue {
+			result.Err = fmt.Errorf("json path %q = %q, want %q", t.cfg.JSONPath, value.String(), t.cfg.JSONPathValue)
+			return result
+		}
+	}
+
+	result.Success = true
+	return result
+}
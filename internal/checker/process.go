@@ -0,0 +1,70 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	gopsprocess "github.com/shirou/gopsutil/v3/process"
+)
+
+// ProcessConfig checks that a process is running, identified either by PID
+// or by name. PID takes precedence when both are set.
+type ProcessConfig struct {
+	PID  int32
+	Name string
+}
+
+type processTarget struct {
+	name     string
+	severity Severity
+	cfg      ProcessConfig
+}
+
+func newProcessTarget(name string, severity Severity, cfg ProcessConfig) *processTarget {
+	return &processTarget{name: name, severity: severity, cfg: cfg}
+}
+
+func (t *processTarget) Name() string { return t.name }
+
+func (t *processTarget) Check(ctx context.Context) Result {
+	start := time.Now()
+	result := Result{Target: t.name, Severity: t.severity, CheckedAt: start}
+
+	if t.cfg.PID > 0 {
+		running, err := gopsprocess.PidExistsWithContext(ctx, t.cfg.PID)
+		result.Latency = time.Since(start)
+		if err != nil {
+			result.Err = fmt.Errorf("check pid %d: %w", t.cfg.PID, err)
+			return result
+		}
+		if !running {
+			result.Err = fmt.Errorf("process with pid %d is not running", t.cfg.PID)
+			return result
+		}
+		result.Success = true
+		return result
+	}
+
+	procs, err := gopsprocess.ProcessesWithContext(ctx)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Err = fmt.Errorf("list processes: %w", err)
+		return result
+	}
+
+	for _, p := range procs {
+		procName, err := p.NameWithContext(ctx)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(procName, t.cfg.Name) {
+			result.Success = true
+			return result
+		}
+	}
+
+	result.Err = fmt.Errorf("no process named %q is running", t.cfg.Name)
+	return result
+}
@@ -0,0 +1,48 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// TCPConfig checks that a TCP port is reachable within Timeout.
+type TCPConfig struct {
+	Address string // host:port
+	Timeout time.Duration
+}
+
+type tcpTarget struct {
+	name     string
+	severity Severity
+	cfg      TCPConfig
+}
+
+func newTCPTarget(name string, severity Severity, cfg TCPConfig) *tcpTarget {
+	return &tcpTarget{name: name, severity: severity, cfg: cfg}
+}
+
+func (t *tcpTarget) Name() string { return t.name }
+
+func (t *tcpTarget) Check(ctx context.Context) Result {
+	start := time.Now()
+	result := Result{Target: t.name, Severity: t.severity, CheckedAt: start}
+
+	timeout := t.cfg.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", t.cfg.Address)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Err = fmt.Errorf("dial %s: %w", t.cfg.Address, err)
+		return result
+	}
+	conn.Close()
+
+	result.Success = true
+	return result
+}
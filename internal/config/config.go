@@ -14,19 +14,136 @@ type Config struct {
 }
 
 type MonitorConfig struct {
-	HAURL      string       `mapstructure:"ha_url"`
-	HAToken    string       `mapstructure:"ha_token"`
-	RetryTimes int          `mapstructure:"retry_times"`
-	Timeout    int          `mapstructure:"timeout"`
-	Schedule   string       `mapstructure:"schedule"`
-	Notify     NotifyConfig `mapstructure:"notify"`
-	Tuya       TuyaConfig   `mapstructure:"tuya"`
+	Schedule string         `mapstructure:"schedule"`
+	Timeout  int            `mapstructure:"timeout"`
+	Targets  []TargetConfig `mapstructure:"targets"`
+	Notify   NotifyConfig   `mapstructure:"notify"`
+	Tuya     TuyaConfig     `mapstructure:"tuya"`
+	MQTT     MQTTConfig     `mapstructure:"mqtt"`
+	Store    StoreConfig    `mapstructure:"store"`
+	Admin    AdminConfig    `mapstructure:"admin"`
+	Actions  []ActionConfig `mapstructure:"actions"`
+}
+
+// TargetConfig describes one health-check target. Only the block matching
+// Type needs to be filled in.
+type TargetConfig struct {
+	Name       string   `mapstructure:"name"`
+	Type       string   `mapstructure:"type"` // http, tcp, process, dns, command
+	RetryTimes int      `mapstructure:"retry_times"`
+	Interval   int      `mapstructure:"interval"`   // seconds
+	Severity   string   `mapstructure:"severity"`   // warning, critical
+	OnFailure  []string `mapstructure:"on_failure"` // "notify" or the name of an entry in monitor.actions
+
+	HTTP    HTTPTargetConfig    `mapstructure:"http"`
+	TCP     TCPTargetConfig     `mapstructure:"tcp"`
+	Process ProcessTargetConfig `mapstructure:"process"`
+	DNS     DNSTargetConfig     `mapstructure:"dns"`
+	Command CommandTargetConfig `mapstructure:"command"`
+}
+
+type HTTPTargetConfig struct {
+	URL                string            `mapstructure:"url"`
+	Method             string            `mapstructure:"method"`
+	Token              string            `mapstructure:"token"`
+	ExpectedStatus     int               `mapstructure:"expected_status"`
+	BodyRegex          string            `mapstructure:"body_regex"`
+	JSONPath           string            `mapstructure:"json_path"`
+	JSONPathValue      string            `mapstructure:"json_path_value"`
+	LatencyThresholdMS int               `mapstructure:"latency_threshold_ms"`
+	Headers            map[string]string `mapstructure:"headers"`
+	TimeoutSeconds     int               `mapstructure:"timeout"`
+}
+
+type TCPTargetConfig struct {
+	Address        string `mapstructure:"address"`
+	TimeoutSeconds int    `mapstructure:"timeout"`
+}
+
+type ProcessTargetConfig struct {
+	PID  int32  `mapstructure:"pid"`
+	Name string `mapstructure:"name"`
+}
+
+type DNSTargetConfig struct {
+	Host           string `mapstructure:"host"`
+	ExpectedIP     string `mapstructure:"expected_ip"`
+	TimeoutSeconds int    `mapstructure:"timeout"`
+}
+
+type CommandTargetConfig struct {
+	Command        string   `mapstructure:"command"`
+	Args           []string `mapstructure:"args"`
+	ExpectedCode   int      `mapstructure:"expected_code"`
+	TimeoutSeconds int      `mapstructure:"timeout"`
+}
+
+// MQTTConfig configures the optional MQTT/Home Assistant discovery
+// integration, used as a second, push-based health signal alongside HTTP
+// polling.
+type MQTTConfig struct {
+	Enabled                bool   `mapstructure:"enabled"`
+	BrokerURL              string `mapstructure:"broker_url"`
+	ClientID               string `mapstructure:"client_id"`
+	Username               string `mapstructure:"username"`
+	Password               string `mapstructure:"password"`
+	StatusTopic            string `mapstructure:"status_topic"`
+	HeartbeatWindowSeconds int    `mapstructure:"heartbeat_window_seconds"`
 }
 
 type NotifyConfig struct {
-	APIURL   string `mapstructure:"api_url"`
-	APIToken string `mapstructure:"api_token"`
-	TopicID  int    `mapstructure:"topic_id"`
+	Channels []ChannelConfig `mapstructure:"channels"`
+}
+
+// ChannelConfig describes one notification channel. Only the block matching
+// Type needs to be filled in.
+type ChannelConfig struct {
+	Name    string `mapstructure:"name"`
+	Type    string `mapstructure:"type"` // bark, telegram, discord, ntfy, email, webhook
+	Enabled bool   `mapstructure:"enabled"`
+
+	Bark     BarkConfig     `mapstructure:"bark"`
+	Telegram TelegramConfig `mapstructure:"telegram"`
+	Discord  DiscordConfig  `mapstructure:"discord"`
+	Ntfy     NtfyConfig     `mapstructure:"ntfy"`
+	Email    EmailConfig    `mapstructure:"email"`
+	Webhook  WebhookConfig  `mapstructure:"webhook"`
+}
+
+type BarkConfig struct {
+	ServerURL string `mapstructure:"server_url"`
+	DeviceKey string `mapstructure:"device_key"`
+}
+
+type TelegramConfig struct {
+	BotToken string `mapstructure:"bot_token"`
+	ChatID   string `mapstructure:"chat_id"`
+}
+
+type DiscordConfig struct {
+	WebhookURL string `mapstructure:"webhook_url"`
+}
+
+type NtfyConfig struct {
+	ServerURL string `mapstructure:"server_url"`
+	Topic     string `mapstructure:"topic"`
+	Priority  string `mapstructure:"priority"`
+}
+
+type EmailConfig struct {
+	SMTPHost string   `mapstructure:"smtp_host"`
+	SMTPPort int      `mapstructure:"smtp_port"`
+	Username string   `mapstructure:"username"`
+	Password string   `mapstructure:"password"`
+	From     string   `mapstructure:"from"`
+	To       []string `mapstructure:"to"`
+}
+
+type WebhookConfig struct {
+	URL          string            `mapstructure:"url"`
+	Method       string            `mapstructure:"method"`
+	Headers      map[string]string `mapstructure:"headers"`
+	BodyTemplate string            `mapstructure:"body_template"`
 }
 
 type TuyaConfig struct {
@@ -38,10 +155,106 @@ type TuyaConfig struct {
 	WaitSeconds int    `mapstructure:"wait_seconds"`
 }
 
+// ActionConfig describes one configured recovery action, referenced by name
+// from a target's on_failure list. Only the block matching Type needs to be
+// filled in.
+type ActionConfig struct {
+	Name            string `mapstructure:"name"`
+	Type            string `mapstructure:"type"` // tuya-switch, shell, ssh, docker-restart, systemd-restart, wol, webhook
+	RetryTimes      int    `mapstructure:"retry_times"`
+	CooldownSeconds int    `mapstructure:"cooldown_seconds"`
+
+	Shell          ShellActionConfig          `mapstructure:"shell"`
+	SSH            SSHActionConfig            `mapstructure:"ssh"`
+	DockerRestart  DockerRestartActionConfig  `mapstructure:"docker_restart"`
+	SystemdRestart SystemdRestartActionConfig `mapstructure:"systemd_restart"`
+	WakeOnLAN      WakeOnLANActionConfig      `mapstructure:"wol"`
+	Webhook        WebhookActionConfig        `mapstructure:"webhook"`
+}
+
+// ShellActionConfig configures a local command run as a recovery action.
+// The tuya-switch action type has no config of its own: it reuses the
+// single Tuya client built from TuyaConfig above.
+type ShellActionConfig struct {
+	Command        string   `mapstructure:"command"`
+	Args           []string `mapstructure:"args"`
+	TimeoutSeconds int      `mapstructure:"timeout"`
+}
+
+type SSHActionConfig struct {
+	Host           string `mapstructure:"host"`
+	Port           int    `mapstructure:"port"`
+	User           string `mapstructure:"user"`
+	PrivateKeyPath string `mapstructure:"private_key_path"`
+	Command        string `mapstructure:"command"`
+	TimeoutSeconds int    `mapstructure:"timeout"`
+}
+
+type DockerRestartActionConfig struct {
+	SocketPath     string `mapstructure:"socket_path"`
+	Container      string `mapstructure:"container"`
+	TimeoutSeconds int    `mapstructure:"timeout"`
+}
+
+type SystemdRestartActionConfig struct {
+	Unit           string `mapstructure:"unit"`
+	TimeoutSeconds int    `mapstructure:"timeout"`
+}
+
+type WakeOnLANActionConfig struct {
+	MAC       string `mapstructure:"mac"`
+	Broadcast string `mapstructure:"broadcast"`
+}
+
+type WebhookActionConfig struct {
+	URL            string            `mapstructure:"url"`
+	Method         string            `mapstructure:"method"`
+	Headers        map[string]string `mapstructure:"headers"`
+	TimeoutSeconds int               `mapstructure:"timeout"`
+}
+
+// StoreConfig selects and configures the backend used to persist failCount,
+// notification dedup state, and the cached Tuya token across restarts.
+type StoreConfig struct {
+	Backend string            `mapstructure:"backend"` // memory, boltdb, redis
+	BoltDB  BoltDBStoreConfig `mapstructure:"boltdb"`
+	Redis   RedisStoreConfig  `mapstructure:"redis"`
+}
+
+type BoltDBStoreConfig struct {
+	Path string `mapstructure:"path"`
+}
+
+type RedisStoreConfig struct {
+	Addr                string `mapstructure:"addr"`
+	Password            string `mapstructure:"password"`
+	DB                  int    `mapstructure:"db"`
+	MaxIdle             int    `mapstructure:"max_idle"`
+	MaxActive           int    `mapstructure:"max_active"`
+	IdleTimeoutSeconds  int    `mapstructure:"idle_timeout"`
+	DialTimeoutSeconds  int    `mapstructure:"dial_timeout"`
+	ReadTimeoutSeconds  int    `mapstructure:"read_timeout"`
+	WriteTimeoutSeconds int    `mapstructure:"write_timeout"`
+	KeyPrefix           string `mapstructure:"key_prefix"`
+}
+
+// AdminConfig configures the optional admin HTTP API used to operate a
+// running monitor: health/status checks, an ad-hoc probe, a manual Tuya
+// restart, and Prometheus metrics.
+type AdminConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	Addr         string `mapstructure:"addr"`
+	Token        string `mapstructure:"token"`
+	ConfirmToken string `mapstructure:"confirm_token"`
+}
+
 type Loader struct {
 	mu     sync.RWMutex
 	config *Config
 	v      *viper.Viper
+
+	onChangeMu sync.Mutex
+	onChange   []func(*Config)
 }
 
 func NewLoader(path string) (*Loader, error) {
@@ -64,7 +277,9 @@ func NewLoader(path string) (*Loader, error) {
 		log.Printf("Config file changed: %s\n", e.Name)
 		if err := l.load(); err != nil {
 			fmt.Printf("Reload config failed: %v\n", err)
+			return
 		}
+		l.notifyChange()
 	})
 	l.v.WatchConfig()
 
@@ -84,6 +299,25 @@ func (l *Loader) load() error {
 	return nil
 }
 
+// OnChange registers a callback to run every time the config file is
+// successfully reloaded. Callbacks run synchronously on the watcher's
+// goroutine, so they should stay quick and non-blocking.
+func (l *Loader) OnChange(fn func(*Config)) {
+	l.onChangeMu.Lock()
+	defer l.onChangeMu.Unlock()
+	l.onChange = append(l.onChange, fn)
+}
+
+func (l *Loader) notifyChange() {
+	cfg := l.Get()
+
+	l.onChangeMu.Lock()
+	defer l.onChangeMu.Unlock()
+	for _, fn := range l.onChange {
+		fn(cfg)
+	}
+}
+
 func (l *Loader) Get() *Config {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
@@ -1,178 +1,607 @@
 package monitor
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"sort"
+	"sync"
 	"time"
 
+	"ha-monitor/internal/action"
+	"ha-monitor/internal/checker"
+	"ha-monitor/internal/mqtt"
+	"ha-monitor/internal/notify"
+	"ha-monitor/internal/store"
 	"ha-monitor/internal/tuya"
 )
 
-type Monitor struct {
-	url         string
-	token       string
-	notifyConf  NotifyConfig
-	tuyaClient  *tuya.Client
-	retryTimes  int
-	timeout     time.Duration
-	failCount   int
-	hasNotified bool
-	client      *http.Client
+// defaultHeartbeatWindow is used when the config leaves the MQTT heartbeat
+// window unset.
+const defaultHeartbeatWindow = 60 * time.Second
+
+// actionStateKeyPrefix namespaces a recovery action's own bookkeeping (last
+// run time, last error, cached Tuya token) in the Store, separate from any
+// target's state, in case a target and an action happen to share a name.
+const actionStateKeyPrefix = "action:"
+
+func actionStateKey(name string) string {
+	return actionStateKeyPrefix + name
 }
 
-type NotifyConfig struct {
-	APIURL   string
-	APIToken string
-	TopicID  int
+type configuredAction struct {
+	action.Action
+	retryTimes int
 }
 
-func isSuccessStatus(code int) bool {
-	return code >= 200 && code < 300
+type Monitor struct {
+	mqttClient *mqtt.Client
+	store      store.Store
+
+	// cfgMu guards checker, notifiers, timeout, and client, all of which
+	// UpdateConfig replaces wholesale on a reload while Check,
+	// fanOutNotify, and handleResult (driven by the cron goroutine and the
+	// admin API's POST /check) read them from a different goroutine. client
+	// in particular is always swapped for a new *http.Client on reload,
+	// never mutated in place: net/http reads Client.Timeout unsynchronized
+	// on every Do, so flipping it on a client already shared with in-flight
+	// notifiers/actions would itself be a race.
+	cfgMu     sync.RWMutex
+	checker   *checker.Checker
+	notifiers []notify.Notifier
+	timeout   time.Duration
+	client    *http.Client
+
+	actionsMu sync.RWMutex
+	actions   map[string]configuredAction
+
+	heartbeatWindow time.Duration
+
+	resultsMu   sync.RWMutex
+	lastResults map[string]checker.Result
+
+	// targetMusMu guards targetMus, the set of per-target mutexes
+	// handleResult takes to serialize its state read-modify-write: Check can
+	// run concurrently from the cron goroutine and the admin API's
+	// POST /check, and without a lock both could load Notified == false for
+	// the same target and both fire a down notification.
+	targetMusMu sync.Mutex
+	targetMus   map[string]*sync.Mutex
 }
 
-func NewMonitor(url string, token string, notify NotifyConfig, tuyaConfig tuya.Config, retryTimes int, timeout int) *Monitor {
+func NewMonitor(targets []checker.TargetConfig, channels []notify.ChannelConfig, tuyaConfig tuya.Config, mqttConfig mqtt.Config, actionConfigs []action.Config, st store.Store, timeout int) (*Monitor, error) {
 	if timeout <= 0 {
 		timeout = 10
 	}
 
+	c, err := checker.NewChecker(targets)
+	if err != nil {
+		return nil, fmt.Errorf("build checker: %w", err)
+	}
+
+	if st == nil {
+		st = store.NewMemoryStore()
+	}
+
 	httpClient := &http.Client{Timeout: time.Duration(timeout) * time.Second}
+	tuyaClient := tuya.NewClient(tuyaConfig, httpClient)
+
+	actions, err := buildActions(actionConfigs, tuyaClient, httpClient, st)
+	if err != nil {
+		return nil, fmt.Errorf("build actions: %w", err)
+	}
+
+	heartbeatWindow := mqttConfig.HeartbeatWindow
+	if heartbeatWindow <= 0 {
+		heartbeatWindow = defaultHeartbeatWindow
+	}
+
+	m := &Monitor{
+		checker:         c,
+		notifiers:       buildNotifiers(channels, httpClient),
+		mqttClient:      mqtt.NewClient(mqttConfig),
+		store:           st,
+		client:          httpClient,
+		timeout:         time.Duration(timeout) * time.Second,
+		actions:         actions,
+		heartbeatWindow: heartbeatWindow,
+		lastResults:     make(map[string]checker.Result),
+		targetMus:       make(map[string]*sync.Mutex),
+	}
+
+	if mqttConfig.Enabled {
+		if err := m.mqttClient.Connect(); err != nil {
+			log.Printf("Failed to connect to MQTT broker: %v", err)
+		}
+	}
+
+	return m, nil
+}
+
+// buildActions constructs every configured recovery action and, for any
+// tuya-switch action, seeds its token cache from the persisted store so a
+// restart doesn't always force a fresh /v1.0/token call.
+func buildActions(configs []action.Config, tuyaClient *tuya.Client, httpClient *http.Client, st store.Store) (map[string]configuredAction, error) {
+	actions := make(map[string]configuredAction, len(configs))
+	for _, cfg := range configs {
+		if _, dup := actions[cfg.Name]; dup {
+			return nil, fmt.Errorf("action %q is configured more than once", cfg.Name)
+		}
+
+		act, err := action.New(cfg, tuyaClient, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("action %q: %w", cfg.Name, err)
+		}
 
-	return &Monitor{
-		url:         url,
-		token:       token,
-		notifyConf:  notify,
-		tuyaClient:  tuya.NewClient(tuyaConfig, httpClient),
-		retryTimes:  retryTimes,
-		timeout:     time.Duration(timeout) * time.Second,
-		client:      httpClient,
-		hasNotified: false,
-		failCount:   0,
+		if tuyaAction, ok := act.(*action.TuyaSwitchAction); ok {
+			state, err := st.GetState(actionStateKey(cfg.Name))
+			if err != nil {
+				log.Printf("Failed to load persisted state for action %q: %v", cfg.Name, err)
+			} else if state.TuyaToken != nil {
+				tuyaAction.LoadToken(tuya.TokenSnapshot{
+					AccessToken:  state.TuyaToken.AccessToken,
+					RefreshToken: state.TuyaToken.RefreshToken,
+					ExpireTime:   state.TuyaToken.ExpireTime,
+				})
+			}
+		}
+
+		retryTimes := cfg.RetryTimes
+		if retryTimes <= 0 {
+			retryTimes = 1
+		}
+		actions[cfg.Name] = configuredAction{Action: act, retryTimes: retryTimes}
 	}
+	return actions, nil
 }
 
+func buildNotifiers(channels []notify.ChannelConfig, httpClient *http.Client) []notify.Notifier {
+	notifiers := make([]notify.Notifier, 0, len(channels))
+	for _, ch := range channels {
+		if !ch.Enabled {
+			continue
+		}
+
+		n, err := notify.New(ch, httpClient)
+		if err != nil {
+			log.Printf("Skipping notify channel %q: %v", ch.Name, err)
+			continue
+		}
+
+		notifiers = append(notifiers, n)
+	}
+	return notifiers
+}
+
+// cfgSnapshot is an immutable copy of the fields UpdateConfig replaces
+// wholesale on a reload, taken under cfgMu so a concurrent Check (from the
+// cron goroutine or the admin API's POST /check) never observes a
+// half-updated Monitor.
+type cfgSnapshot struct {
+	checker   *checker.Checker
+	notifiers []notify.Notifier
+	timeout   time.Duration
+}
+
+func (m *Monitor) snapshot() cfgSnapshot {
+	m.cfgMu.RLock()
+	defer m.cfgMu.RUnlock()
+	return cfgSnapshot{checker: m.checker, notifiers: m.notifiers, timeout: m.timeout}
+}
+
+// Check probes every configured target once and drives each target's
+// on_failure actions once it has failed retry_times times in a row.
 func (m *Monitor) Check() error {
-	req, err := http.NewRequest("GET", m.url, nil)
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+	mc := m.snapshot()
+
+	ctx, cancel := context.WithTimeout(context.Background(), mc.timeout)
+	defer cancel()
+
+	var firstErr error
+	for _, result := range mc.checker.RunOnce(ctx) {
+		if err := m.handleResult(mc, result); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
+	return firstErr
+}
 
-	req.Header.Add("Authorization", "Bearer "+m.token)
+// Validate re-runs all targets until they all pass or retryTimeout elapses.
+// It does not drive on_failure actions; it is meant to be invoked as a
+// one-shot check, e.g. to confirm recovery after a manual remediation.
+func (m *Monitor) Validate(retryTimeout, sleep time.Duration) bool {
+	mc := m.snapshot()
 
-	resp, err := m.client.Do(req)
+	ctx, cancel := context.WithTimeout(context.Background(), retryTimeout+mc.timeout)
+	defer cancel()
+
+	results, ok := mc.checker.Validate(ctx, retryTimeout, sleep)
+	for _, r := range results {
+		if r.Success {
+			log.Printf("Target %q: OK (latency %s)", r.Target, r.Latency)
+		} else {
+			log.Printf("Target %q: FAIL (%v)", r.Target, r.Err)
+		}
+	}
+	return ok
+}
+
+// targetLock returns the mutex serializing handleResult's state
+// read-modify-write for target, creating it on first use.
+func (m *Monitor) targetLock(target string) *sync.Mutex {
+	m.targetMusMu.Lock()
+	defer m.targetMusMu.Unlock()
+
+	mu, ok := m.targetMus[target]
+	if !ok {
+		mu = &sync.Mutex{}
+		m.targetMus[target] = mu
+	}
+	return mu
+}
+
+func (m *Monitor) handleResult(mc cfgSnapshot, result checker.Result) error {
+	cfg, ok := mc.checker.TargetConfig(result.Target)
+	if !ok {
+		return nil
+	}
+
+	mu := m.targetLock(result.Target)
+	mu.Lock()
+	defer mu.Unlock()
+
+	m.resultsMu.Lock()
+	m.lastResults[result.Target] = result
+	m.resultsMu.Unlock()
+
+	state, err := m.store.GetState(result.Target)
 	if err != nil {
-		m.failCount++
-		if m.failCount >= m.retryTimes {
-			if m.tuyaClient != nil {
-				if err := m.tuyaClient.RestartDevice(); err != nil {
-					log.Printf("Failed to restart server: %v", err)
-				}
-			}
+		log.Printf("Failed to load persisted state for %q: %v", result.Target, err)
+	}
+
+	if result.Success {
+		wasNotified := state.Notified
+		state.FailCount = 0
+		state.Notified = false
+		if err := m.store.SetState(result.Target, state); err != nil {
+			log.Printf("Failed to persist state for %q: %v", result.Target, err)
+		}
 
-			if err := m.notifyDown(); err != nil {
-				return fmt.Errorf("notification failed: %w", err)
+		if wasNotified {
+			if err := m.notifyUp(mc, result.Target); err != nil {
+				log.Printf("Failed to send recovery notification for %q: %v", result.Target, err)
 			}
-			m.hasNotified = true
 		}
-		return err
+
+		log.Printf("Target %q is healthy (latency %s)", result.Target, result.Latency)
+		return nil
 	}
-	defer resp.Body.Close()
 
-	if !isSuccessStatus(resp.StatusCode) {
-		m.failCount++
-		if m.failCount >= m.retryTimes {
-			if m.tuyaClient != nil {
-				if err := m.tuyaClient.RestartDevice(); err != nil {
-					log.Printf("Failed to restart server: %v", err)
-				}
-			}
+	log.Printf("Target %q check failed: %v", result.Target, result.Err)
+
+	state.FailCount++
+	if err := m.store.SetState(result.Target, state); err != nil {
+		log.Printf("Failed to persist state for %q: %v", result.Target, err)
+	}
 
-			if err := m.notifyDown(); err != nil {
-				return fmt.Errorf("notification failed: %w", err)
+	retryTimes := cfg.RetryTimes
+	if retryTimes <= 0 {
+		retryTimes = 1
+	}
+	if state.FailCount < retryTimes {
+		return result.Err
+	}
+
+	m.runOnFailureActions(mc, result.Target, cfg, result.Err, state)
+
+	return result.Err
+}
+
+// runOnFailureActions walks a target's on_failure list in order. "notify" is
+// handled directly, but only once per down transition: state.Notified is
+// already true on every later tick of an ongoing outage (FailCount keeps
+// climbing past retryTimes until recovery), so without this guard the same
+// "down" alert would go out on every cron tick for as long as the target
+// stays down. Anything other than "notify" must name an entry under
+// monitor.actions. Actions run with their own background context,
+// independent of the short per-probe-cycle timeout, since a remediation (an
+// SSH command, a container restart) may legitimately take far longer than a
+// single health check. After each action runs, the target is rechecked, and
+// the chain aborts as soon as it comes back healthy.
+func (m *Monitor) runOnFailureActions(mc cfgSnapshot, target string, cfg checker.TargetConfig, cause error, state store.State) {
+	for _, name := range cfg.OnFailure {
+		if name == checker.ActionNotify {
+			if state.Notified {
+				continue
+			}
+			if err := m.notifyDown(mc, target, cause); err != nil {
+				log.Printf("Failed to send down notification for %q: %v", target, err)
 			}
-			m.hasNotified = true
+			state.Notified = true
+			state.LastNotifyAt = time.Now()
+			if err := m.store.SetState(target, state); err != nil {
+				log.Printf("Failed to persist state for %q: %v", target, err)
+			}
+			continue
 		}
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
 
-	// 服务恢复正常时
-	if m.hasNotified {
-		if err := m.notifyUp(); err != nil {
-			log.Printf("Failed to send recovery notification: %v", err)
+		m.actionsMu.RLock()
+		act, ok := m.actions[string(name)]
+		m.actionsMu.RUnlock()
+		if !ok {
+			log.Printf("Target %q: unknown on_failure action %q", target, name)
+			continue
+		}
+
+		if m.mqttClient != nil && m.mqttClient.HasRecentHeartbeat(m.heartbeatWindow) {
+			log.Printf("Target %q failed but MQTT heartbeat from HomeAssistant is still recent, skipping recovery action %q", target, act.Name())
+			continue
+		}
+
+		if !m.runAction(act, target, cause) {
+			continue
+		}
+
+		checkCtx, cancel := context.WithTimeout(context.Background(), mc.timeout)
+		result, ok := mc.checker.CheckOne(checkCtx, target)
+		cancel()
+		if ok && result.Success {
+			log.Printf("Target %q recovered after action %q, aborting remaining on_failure actions", target, act.Name())
+			return
 		}
-		m.hasNotified = false
-		m.failCount = 0 // 只在服务恢复时重置计数
 	}
+}
 
-	log.Printf("HomeAssistant service is healthy, status code: %d", resp.StatusCode)
-	return nil
+// runAction checks an action's cooldown and, if it isn't in cooldown,
+// executes and persists it via executeAction. It returns false if the
+// action was skipped because it's still in cooldown.
+func (m *Monitor) runAction(act configuredAction, target string, cause error) bool {
+	key := actionStateKey(act.Name())
+
+	state, err := m.store.GetState(key)
+	if err != nil {
+		log.Printf("Failed to load persisted state for action %q: %v", act.Name(), err)
+	}
+
+	if cooldown := act.Cooldown(); cooldown > 0 && !state.LastActionRun.IsZero() && time.Since(state.LastActionRun) < cooldown {
+		log.Printf("Action %q skipped for target %q: still within %s cooldown", act.Name(), target, cooldown)
+		return false
+	}
+
+	reason := fmt.Sprintf("target %q failed health check: %v", target, cause)
+	m.executeAction(context.Background(), act, state, fmt.Sprintf("target %q", target), reason)
+	return true
 }
 
-func (m *Monitor) notifyDown() error {
-	payload := map[string]interface{}{
-		"platform": "wechat",
-		"summary":  "HomeAssistant服务异常",
-		"content":  fmt.Sprintf("HomeAssistant service is down after %d retries", m.retryTimes),
-		"extra": map[string]interface{}{
-			"topic_id": m.notifyConf.TopicID,
-		},
+// executeAction runs act, retrying up to act.retryTimes, and persists the
+// outcome (last run time, last error, and any refreshed Tuya token) to
+// state, which the caller must have already loaded. ctx is independent of
+// any single health-check cycle, since a remediation may run far longer
+// than a probe.
+func (m *Monitor) executeAction(ctx context.Context, act configuredAction, state store.State, logLabel, reason string) error {
+	var runErr error
+	for attempt := 1; attempt <= act.retryTimes; attempt++ {
+		runErr = act.Execute(ctx, reason)
+		if runErr == nil {
+			break
+		}
+		log.Printf("Action %q attempt %d/%d for %s failed: %v", act.Name(), attempt, act.retryTimes, logLabel, runErr)
+	}
+
+	state.LastActionRun = time.Now()
+	if runErr != nil {
+		state.LastError = runErr.Error()
+	} else {
+		state.LastError = ""
 	}
-	return m.sendNotification(payload)
+	if tuyaAction, ok := act.Action.(*action.TuyaSwitchAction); ok {
+		if snap, ok := tuyaAction.SnapshotToken(); ok {
+			state.TuyaToken = &store.TuyaToken{
+				AccessToken:  snap.AccessToken,
+				RefreshToken: snap.RefreshToken,
+				ExpireTime:   snap.ExpireTime,
+			}
+		}
+	}
+
+	if err := m.store.SetState(actionStateKey(act.Name()), state); err != nil {
+		log.Printf("Failed to persist state for action %q: %v", act.Name(), err)
+	}
+
+	return runErr
 }
 
-func (m *Monitor) notifyUp() error {
-	payload := map[string]interface{}{
-		"platform": "wechat",
-		"summary":  "HomeAssistant服务已恢复",
-		"content":  "HomeAssistant service has recovered",
-		"extra": map[string]interface{}{
-			"topic_id": m.notifyConf.TopicID,
-		},
+// RestartTuya power-cycles the configured tuya-switch action immediately,
+// bypassing the MQTT heartbeat check and cooldown. It is meant for the admin
+// API's manual restart endpoint, which gates access with its own confirm
+// token.
+func (m *Monitor) RestartTuya() error {
+	m.actionsMu.RLock()
+	defer m.actionsMu.RUnlock()
+
+	for _, act := range m.actions {
+		if _, ok := act.Action.(*action.TuyaSwitchAction); ok {
+			key := actionStateKey(act.Name())
+			state, err := m.store.GetState(key)
+			if err != nil {
+				log.Printf("Failed to load persisted state for action %q: %v", act.Name(), err)
+			}
+			return m.executeAction(context.Background(), act, state, "manual restart", "manual restart via admin API")
+		}
 	}
-	return m.sendNotification(payload)
+	return fmt.Errorf("no tuya-switch action is configured")
 }
 
-func (m *Monitor) sendNotification(payload map[string]interface{}) error {
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		return err
+// Close releases the monitor's long-lived MQTT connection, publishing a
+// retained "offline" state before disconnecting so Home Assistant's
+// availability sensor reflects a clean shutdown rather than going stale.
+func (m *Monitor) Close() {
+	m.mqttClient.Close()
+}
+
+func (m *Monitor) notifyDown(mc cfgSnapshot, target string, cause error) error {
+	return m.fanOutNotify(mc, notify.Event{
+		Type:    notify.EventDown,
+		Summary: fmt.Sprintf("%s 异常", target),
+		Content: fmt.Sprintf("target %q failed health check: %v", target, cause),
+		Time:    time.Now(),
+	})
+}
+
+func (m *Monitor) notifyUp(mc cfgSnapshot, target string) error {
+	return m.fanOutNotify(mc, notify.Event{
+		Type:    notify.EventUp,
+		Summary: fmt.Sprintf("%s 已恢复", target),
+		Content: fmt.Sprintf("target %q has recovered", target),
+		Time:    time.Now(),
+	})
+}
+
+// fanOutNotify delivers event to every configured channel in parallel and
+// aggregates the errors, so a single failing channel never stops the rest
+// from being notified.
+func (m *Monitor) fanOutNotify(mc cfgSnapshot, event notify.Event) error {
+	if len(mc.notifiers) == 0 {
+		return nil
 	}
 
-	req, err := http.NewRequest("POST", m.notifyConf.APIURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+	ctx, cancel := context.WithTimeout(context.Background(), mc.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(mc.notifiers))
+
+	for i, n := range mc.notifiers {
+		wg.Add(1)
+		go func(i int, n notify.Notifier) {
+			defer wg.Done()
+			if err := n.Notify(ctx, event); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", n.Name(), err)
+			}
+		}(i, n)
 	}
+	wg.Wait()
 
-	req.Header.Set("X-API-Token", m.notifyConf.APIToken)
-	req.Header.Set("Content-Type", "application/json")
+	return errors.Join(errs...)
+}
 
-	resp, err := m.client.Do(req)
+// UpdateConfig refreshes the HTTP-based dependencies on a config reload. The
+// MQTT connection and the store are left untouched: the MQTT connection is
+// long-lived and shouldn't be torn down on every reload, and the
+// store's whole purpose is to survive across config reloads and restarts.
+// checker, notifiers, timeout, and client are swapped in under cfgMu, since
+// this runs on viper's independent config-watcher goroutine, concurrently
+// with Check (the cron goroutine and the admin API's POST /check). client is
+// a brand new *http.Client rather than the old one with its Timeout field
+// flipped, so notifiers/actions/tuya requests already in flight against the
+// old client are never affected by the new timeout.
+func (m *Monitor) UpdateConfig(targets []checker.TargetConfig, channels []notify.ChannelConfig, tuyaConfig tuya.Config, actionConfigs []action.Config, timeout int) error {
+	c, err := checker.NewChecker(targets)
 	if err != nil {
-		return err
+		return fmt.Errorf("build checker: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if !isSuccessStatus(resp.StatusCode) {
-		return fmt.Errorf("notification API returned status code: %d", resp.StatusCode)
+	if timeout <= 0 {
+		timeout = 10
 	}
+	newTimeout := time.Duration(timeout) * time.Second
+	newClient := &http.Client{Timeout: newTimeout}
+
+	tuyaClient := tuya.NewClient(tuyaConfig, newClient)
+	actions, err := buildActions(actionConfigs, tuyaClient, newClient, m.store)
+	if err != nil {
+		return fmt.Errorf("build actions: %w", err)
+	}
+	notifiers := buildNotifiers(channels, newClient)
+
+	m.cfgMu.Lock()
+	m.checker = c
+	m.notifiers = notifiers
+	m.timeout = newTimeout
+	m.client = newClient
+	m.cfgMu.Unlock()
+
+	m.actionsMu.Lock()
+	m.actions = actions
+	m.actionsMu.Unlock()
 
 	return nil
 }
 
-func (m *Monitor) UpdateConfig(url string, token string, notify NotifyConfig, tuyaConfig tuya.Config, retryTimes int, timeout int) {
-	m.url = url
-	m.token = token
-	m.notifyConf = notify
-	m.tuyaClient = tuya.NewClient(tuyaConfig, m.client)
-	m.retryTimes = retryTimes
-	if timeout <= 0 {
-		timeout = 10
+// TargetStatus summarizes a target's last known check result, for the admin
+// API's /status and /metrics endpoints.
+type TargetStatus struct {
+	Name         string
+	Severity     checker.Severity
+	Healthy      bool
+	FailCount    int
+	LastCheck    time.Time
+	LastError    string
+	LastNotifyAt time.Time
+}
+
+// ActionStatus summarizes a recovery action's last known run, for the admin
+// API's /status endpoint.
+type ActionStatus struct {
+	Name          string
+	LastActionRun time.Time
+	LastError     string
+}
+
+// Status summarizes the monitor's current state across all targets and
+// recovery actions.
+type Status struct {
+	Targets []TargetStatus
+	Actions []ActionStatus
+}
+
+// Status reports the last known result of every target that has been
+// checked at least once, plus every configured action's last run.
+func (m *Monitor) Status() Status {
+	m.resultsMu.RLock()
+	targets := make([]TargetStatus, 0, len(m.lastResults))
+	for name, result := range m.lastResults {
+		state, err := m.store.GetState(name)
+		if err != nil {
+			log.Printf("Failed to load persisted state for %q: %v", name, err)
+		}
+
+		ts := TargetStatus{
+			Name:         name,
+			Severity:     result.Severity,
+			Healthy:      result.Success,
+			FailCount:    state.FailCount,
+			LastCheck:    result.CheckedAt,
+			LastNotifyAt: state.LastNotifyAt,
+		}
+		if result.Err != nil {
+			ts.LastError = result.Err.Error()
+		}
+		targets = append(targets, ts)
 	}
-	m.timeout = time.Duration(timeout) * time.Second
-	m.client.Timeout = m.timeout
+	m.resultsMu.RUnlock()
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Name < targets[j].Name })
+
+	m.actionsMu.RLock()
+	actions := make([]ActionStatus, 0, len(m.actions))
+	for name := range m.actions {
+		state, err := m.store.GetState(actionStateKey(name))
+		if err != nil {
+			log.Printf("Failed to load persisted state for action %q: %v", name, err)
+		}
+		actions = append(actions, ActionStatus{
+			Name:          name,
+			LastActionRun: state.LastActionRun,
+			LastError:     state.LastError,
+		})
+	}
+	m.actionsMu.RUnlock()
+	sort.Slice(actions, func(i, j int) bool { return actions[i].Name < actions[j].Name })
+
+	return Status{Targets: targets, Actions: actions}
 }
 
 func init() {
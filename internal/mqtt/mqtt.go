@@ -0,0 +1,153 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// Config configures the MQTT connection used to talk to Home Assistant.
+type Config struct {
+	Enabled     bool
+	BrokerURL   string
+	ClientID    string
+	Username    string
+	Password    string
+	StatusTopic string // HA's LWT topic, e.g. homeassistant/status
+
+	// HeartbeatWindow tunes how the monitor uses this connection as a
+	// secondary health signal; see monitor.Monitor.
+	HeartbeatWindow time.Duration
+}
+
+const (
+	discoveryTopic = "homeassistant/binary_sensor/ha_monitor/config"
+	stateTopic     = "ha-monitor/state"
+)
+
+type discoveryPayload struct {
+	Name        string `json:"name"`
+	UniqueID    string `json:"unique_id"`
+	DeviceClass string `json:"device_class"`
+	StateTopic  string `json:"state_topic"`
+	PayloadOn   string `json:"payload_on"`
+	PayloadOff  string `json:"payload_off"`
+}
+
+// Client wires a paho MQTT connection to the Home Assistant status topic and
+// publishes this monitor's own availability via MQTT discovery.
+type Client struct {
+	cfg    Config
+	client paho.Client
+
+	mu            sync.RWMutex
+	lastHeartbeat time.Time
+}
+
+func NewClient(cfg Config) *Client {
+	c := &Client{cfg: cfg}
+	if !cfg.Enabled {
+		return c
+	}
+
+	opts := paho.NewClientOptions().
+		AddBroker(cfg.BrokerURL).
+		SetClientID(cfg.ClientID).
+		SetUsername(cfg.Username).
+		SetPassword(cfg.Password).
+		SetAutoReconnect(true).
+		SetOnConnectHandler(c.onConnect).
+		SetWill(stateTopic, "offline", 0, true)
+
+	c.client = paho.NewClient(opts)
+	return c
+}
+
+func (c *Client) Connect() error {
+	if !c.cfg.Enabled {
+		return nil
+	}
+
+	token := c.client.Connect()
+	token.Wait()
+	return token.Error()
+}
+
+func (c *Client) Close() {
+	if !c.cfg.Enabled || c.client == nil {
+		return
+	}
+
+	c.publishState(false)
+	c.client.Disconnect(250)
+}
+
+// HasRecentHeartbeat reports whether an "online" status message arrived
+// within window; other payloads on the status topic are ignored.
+func (c *Client) HasRecentHeartbeat(window time.Duration) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return !c.lastHeartbeat.IsZero() && time.Since(c.lastHeartbeat) < window
+}
+
+func (c *Client) onConnect(cl paho.Client) {
+	c.publishDiscovery()
+	c.publishState(true)
+
+	if c.cfg.StatusTopic == "" {
+		return
+	}
+
+	if token := cl.Subscribe(c.cfg.StatusTopic, 0, c.handleStatusMessage); token.Wait() && token.Error() != nil {
+		log.Printf("mqtt: failed to subscribe to %s: %v", c.cfg.StatusTopic, token.Error())
+	}
+}
+
+func (c *Client) handleStatusMessage(_ paho.Client, msg paho.Message) {
+	if string(msg.Payload()) != "online" {
+		return
+	}
+
+	c.mu.Lock()
+	c.lastHeartbeat = time.Now()
+	c.mu.Unlock()
+}
+
+func (c *Client) publishDiscovery() {
+	payload := discoveryPayload{
+		Name:        "HA Monitor",
+		UniqueID:    "ha_monitor_connectivity",
+		DeviceClass: "connectivity",
+		StateTopic:  stateTopic,
+		PayloadOn:   "online",
+		PayloadOff:  "offline",
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("mqtt: marshal discovery payload: %v", err)
+		return
+	}
+
+	token := c.client.Publish(discoveryTopic, 0, true, data)
+	token.Wait()
+	if err := token.Error(); err != nil {
+		log.Printf("mqtt: publish discovery config: %v", err)
+	}
+}
+
+func (c *Client) publishState(online bool) {
+	state := "offline"
+	if online {
+		state = "online"
+	}
+
+	token := c.client.Publish(stateTopic, 0, true, state)
+	token.Wait()
+	if err := token.Error(); err != nil {
+		log.Printf("mqtt: publish state: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// BarkConfig configures delivery to a Bark (https://bark.day.app) device.
+type BarkConfig struct {
+	ServerURL string // e.g. https://api.day.app
+	DeviceKey string
+}
+
+type barkNotifier struct {
+	name   string
+	cfg    BarkConfig
+	client *http.Client
+}
+
+func newBarkNotifier(name string, cfg BarkConfig, client *http.Client) *barkNotifier {
+	return &barkNotifier{name: name, cfg: cfg, client: client}
+}
+
+func (n *barkNotifier) Name() string { return n.name }
+
+func (n *barkNotifier) Notify(ctx context.Context, event Event) error {
+	payload := map[string]interface{}{
+		"title": event.Summary,
+		"body":  event.Content,
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s", n.cfg.ServerURL, n.cfg.DeviceKey)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !isSuccessStatus(resp.StatusCode) {
+		return fmt.Errorf("bark API returned status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
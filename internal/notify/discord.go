@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// DiscordConfig configures delivery to a Discord incoming webhook.
+type DiscordConfig struct {
+	WebhookURL string
+}
+
+type discordNotifier struct {
+	name   string
+	cfg    DiscordConfig
+	client *http.Client
+}
+
+func newDiscordNotifier(name string, cfg DiscordConfig, client *http.Client) *discordNotifier {
+	return &discordNotifier{name: name, cfg: cfg, client: client}
+}
+
+func (n *discordNotifier) Name() string { return n.name }
+
+func (n *discordNotifier) Notify(ctx context.Context, event Event) error {
+	payload := map[string]interface{}{
+		"content": fmt.Sprintf("**%s**\n%s", event.Summary, event.Content),
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.cfg.WebhookURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !isSuccessStatus(resp.StatusCode) {
+		return fmt.Errorf("discord webhook returned status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
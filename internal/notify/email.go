@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+)
+
+// EmailConfig configures delivery over SMTP.
+type EmailConfig struct {
+	SMTPHost string
+	SMTPPort int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+type emailNotifier struct {
+	name string
+	cfg  EmailConfig
+}
+
+func newEmailNotifier(name string, cfg EmailConfig) *emailNotifier {
+	return &emailNotifier{name: name, cfg: cfg}
+}
+
+func (n *emailNotifier) Name() string { return n.name }
+
+func (n *emailNotifier) Notify(ctx context.Context, event Event) error {
+	addr := fmt.Sprintf("%s:%d", n.cfg.SMTPHost, n.cfg.SMTPPort)
+	auth := smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.SMTPHost)
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n",
+		n.cfg.From, joinAddrs(n.cfg.To), event.Summary, event.Content)
+
+	if err := smtp.SendMail(addr, auth, n.cfg.From, n.cfg.To, []byte(msg)); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+
+	return nil
+}
+
+func joinAddrs(addrs []string) string {
+	out := ""
+	for i, a := range addrs {
+		if i > 0 {
+			out += ", "
+		}
+		out += a
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventDown EventType = "down"
+	EventUp   EventType = "up"
+)
+
+// Event describes a single health transition to be broadcast to notifiers.
+type Event struct {
+	Type    EventType
+	Summary string
+	Content string
+	Time    time.Time
+}
+
+// Notifier delivers an Event to a single downstream channel.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, event Event) error
+}
+
+// ChannelConfig describes one configured notification channel. Only the
+// struct matching Type needs to be populated.
+type ChannelConfig struct {
+	Name    string
+	Type    string // bark, telegram, discord, ntfy, email, webhook
+	Enabled bool
+
+	Bark     BarkConfig
+	Telegram TelegramConfig
+	Discord  DiscordConfig
+	Ntfy     NtfyConfig
+	Email    EmailConfig
+	Webhook  WebhookConfig
+}
+
+// New builds the concrete Notifier for a channel config.
+func New(cfg ChannelConfig, httpClient *http.Client) (Notifier, error) {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = cfg.Type
+	}
+
+	switch cfg.Type {
+	case "bark":
+		return newBarkNotifier(name, cfg.Bark, httpClient), nil
+	case "telegram":
+		return newTelegramNotifier(name, cfg.Telegram, httpClient), nil
+	case "discord":
+		return newDiscordNotifier(name, cfg.Discord, httpClient), nil
+	case "ntfy":
+		return newNtfyNotifier(name, cfg.Ntfy, httpClient), nil
+	case "email":
+		return newEmailNotifier(name, cfg.Email), nil
+	case "webhook":
+		return newWebhookNotifier(name, cfg.Webhook, httpClient)
+	default:
+		return nil, fmt.Errorf("unknown notifier type: %q", cfg.Type)
+	}
+}
+
+func isSuccessStatus(code int) bool {
+	return code >= 200 && code < 300
+}
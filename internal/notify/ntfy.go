@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NtfyConfig configures delivery via an ntfy.sh (or self-hosted) topic.
+type NtfyConfig struct {
+	ServerURL string // e.g. https://ntfy.sh
+	Topic     string
+	Priority  string // e.g. default, high, urgent
+}
+
+type ntfyNotifier struct {
+	name   string
+	cfg    NtfyConfig
+	client *http.Client
+}
+
+func newNtfyNotifier(name string, cfg NtfyConfig, client *http.Client) *ntfyNotifier {
+	return &ntfyNotifier{name: name, cfg: cfg, client: client}
+}
+
+func (n *ntfyNotifier) Name() string { return n.name }
+
+func (n *ntfyNotifier) Notify(ctx context.Context, event Event) error {
+	url := fmt.Sprintf("%s/%s", n.cfg.ServerURL, n.cfg.Topic)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(event.Content))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Title", event.Summary)
+	if n.cfg.Priority != "" {
+		req.Header.Set("Priority", n.cfg.Priority)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !isSuccessStatus(resp.StatusCode) {
+		return fmt.Errorf("ntfy server returned status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
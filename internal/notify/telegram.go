@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// TelegramConfig configures delivery via the Telegram Bot API.
+type TelegramConfig struct {
+	BotToken string
+	ChatID   string
+}
+
+type telegramNotifier struct {
+	name   string
+	cfg    TelegramConfig
+	client *http.Client
+}
+
+func newTelegramNotifier(name string, cfg TelegramConfig, client *http.Client) *telegramNotifier {
+	return &telegramNotifier{name: name, cfg: cfg, client: client}
+}
+
+func (n *telegramNotifier) Name() string { return n.name }
+
+func (n *telegramNotifier) Notify(ctx context.Context, event Event) error {
+	payload := map[string]interface{}{
+		"chat_id": n.cfg.ChatID,
+		"text":    fmt.Sprintf("%s\n%s", event.Summary, event.Content),
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", n.cfg.BotToken)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !isSuccessStatus(resp.StatusCode) {
+		return fmt.Errorf("telegram API returned status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+)
+
+// templateFuncs is available to every webhook body template (the default one
+// and any user-supplied one) as the "json" pipeline function, so a field
+// like Event.Content - which routinely contains quotes or newlines from an
+// error message - gets properly JSON-escaped instead of interpolated raw
+// into a JSON string literal.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	},
+}
+
+// WebhookConfig configures delivery to a generic HTTP endpoint with a
+// user-supplied JSON body template and headers.
+type WebhookConfig struct {
+	URL          string
+	Method       string // defaults to POST
+	Headers      map[string]string
+	BodyTemplate string // text/template, rendered against Event
+}
+
+type webhookNotifier struct {
+	name   string
+	cfg    WebhookConfig
+	tmpl   *template.Template
+	client *http.Client
+}
+
+func newWebhookNotifier(name string, cfg WebhookConfig, client *http.Client) (*webhookNotifier, error) {
+	body := cfg.BodyTemplate
+	if body == "" {
+		body = `{"summary":{{.Summary | json}},"content":{{.Content | json}},"type":{{.Type | json}}}`
+	}
+
+	tmpl, err := template.New("webhook-" + name).Funcs(templateFuncs).Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("parse webhook body template: %w", err)
+	}
+
+	return &webhookNotifier{name: name, cfg: cfg, tmpl: tmpl, client: client}, nil
+}
+
+func (n *webhookNotifier) Name() string { return n.name }
+
+func (n *webhookNotifier) Notify(ctx context.Context, event Event) error {
+	var buf bytes.Buffer
+	if err := n.tmpl.Execute(&buf, event); err != nil {
+		return fmt.Errorf("render webhook body: %w", err)
+	}
+
+	method := n.cfg.Method
+	if method == "" {
+		method = "POST"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, n.cfg.URL, &buf)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range n.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !isSuccessStatus(resp.StatusCode) {
+		return fmt.Errorf("webhook returned status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
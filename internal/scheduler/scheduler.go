@@ -0,0 +1,70 @@
+// Package scheduler wraps a cron.Cron so a single recurring job's spec can
+// be swapped out at runtime, e.g. after a config hot-reload changes
+// monitor.schedule, without restarting the process.
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Scheduler runs one recurring job whose cron spec can be changed later via
+// Reschedule.
+type Scheduler struct {
+	cron *cron.Cron
+
+	mu      sync.Mutex
+	entryID cron.EntryID
+	spec    string
+	job     func()
+}
+
+func New() *Scheduler {
+	return &Scheduler{cron: cron.New(cron.WithSeconds())}
+}
+
+// Start schedules job to run on spec and starts the underlying cron runner.
+func (s *Scheduler) Start(spec string, job func()) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, err := s.cron.AddFunc(spec, job)
+	if err != nil {
+		return fmt.Errorf("add cron job: %w", err)
+	}
+
+	s.entryID = id
+	s.spec = spec
+	s.job = job
+	s.cron.Start()
+	return nil
+}
+
+// Reschedule atomically swaps the running job onto a new spec. It is a
+// no-op if spec hasn't changed.
+func (s *Scheduler) Reschedule(spec string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if spec == s.spec {
+		return nil
+	}
+
+	id, err := s.cron.AddFunc(spec, s.job)
+	if err != nil {
+		return fmt.Errorf("add cron job: %w", err)
+	}
+
+	s.cron.Remove(s.entryID)
+	s.entryID = id
+	s.spec = spec
+	return nil
+}
+
+// Stop stops the underlying cron runner, waiting for any in-flight job to
+// finish.
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
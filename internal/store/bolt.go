@@ -0,0 +1,68 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var statesBucket = []byte("states")
+
+// BoltStore is the default persistent backend: a single local file, no
+// external service required.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open bolt db: %w", err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(statesBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create bucket: %w", err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) GetState(target string) (State, error) {
+	var state State
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(statesBucket).Get([]byte(target))
+		if data == nil {
+			return nil
+		}
+		return json.Unmarshal(data, &state)
+	})
+	if err != nil {
+		return State{}, fmt.Errorf("get state for %q: %w", target, err)
+	}
+	return state, nil
+}
+
+func (s *BoltStore) SetState(target string, state State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal state for %q: %w", target, err)
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(statesBucket).Put([]byte(target), data)
+	})
+	if err != nil {
+		return fmt.Errorf("set state for %q: %w", target, err)
+	}
+	return nil
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
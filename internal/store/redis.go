@@ -0,0 +1,95 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// RedisConfig configures the pooled Redis connection used by RedisStore.
+type RedisConfig struct {
+	Addr         string
+	Password     string
+	DB           int
+	MaxIdle      int
+	MaxActive    int
+	IdleTimeout  time.Duration
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	KeyPrefix    string
+}
+
+// RedisStore persists state in Redis, for deployments that already run a
+// Redis instance and would rather not manage a local BoltDB file.
+type RedisStore struct {
+	pool   *redis.Pool
+	prefix string
+}
+
+func NewRedisStore(cfg RedisConfig) *RedisStore {
+	pool := &redis.Pool{
+		MaxIdle:     cfg.MaxIdle,
+		MaxActive:   cfg.MaxActive,
+		IdleTimeout: cfg.IdleTimeout,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", cfg.Addr,
+				redis.DialPassword(cfg.Password),
+				redis.DialDatabase(cfg.DB),
+				redis.DialConnectTimeout(cfg.DialTimeout),
+				redis.DialReadTimeout(cfg.ReadTimeout),
+				redis.DialWriteTimeout(cfg.WriteTimeout),
+			)
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			_, err := c.Do("PING")
+			return err
+		},
+	}
+
+	return &RedisStore{pool: pool, prefix: cfg.KeyPrefix}
+}
+
+func (s *RedisStore) key(target string) string {
+	return s.prefix + target
+}
+
+func (s *RedisStore) GetState(target string) (State, error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	data, err := redis.Bytes(conn.Do("GET", s.key(target)))
+	if err == redis.ErrNil {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, fmt.Errorf("get state for %q: %w", target, err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("unmarshal state for %q: %w", target, err)
+	}
+	return state, nil
+}
+
+func (s *RedisStore) SetState(target string, state State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal state for %q: %w", target, err)
+	}
+
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	if _, err := conn.Do("SET", s.key(target), data); err != nil {
+		return fmt.Errorf("set state for %q: %w", target, err)
+	}
+	return nil
+}
+
+func (s *RedisStore) Close() error {
+	return s.pool.Close()
+}
@@ -0,0 +1,63 @@
+// Package store persists per-target and per-action monitor state so a
+// restart of the binary doesn't reset failCount/notified and cause
+// duplicate "down" notifications or a premature recovery action.
+package store
+
+import (
+	"sync"
+	"time"
+)
+
+// State is the durable bookkeeping kept per key across restarts. A key is
+// either a target name (FailCount/Notified/LastNotifyAt) or a recovery
+// action name (LastActionRun/LastError/TuyaToken), reusing the same struct
+// since both are simple, infrequently-updated JSON blobs.
+type State struct {
+	FailCount     int
+	Notified      bool
+	LastNotifyAt  time.Time
+	LastActionRun time.Time
+	LastError     string
+	TuyaToken     *TuyaToken
+}
+
+// TuyaToken is the persistable form of the Tuya client's cached access
+// token, so a restart doesn't always force a fresh /v1.0/token call.
+type TuyaToken struct {
+	AccessToken  string
+	RefreshToken string
+	ExpireTime   time.Time
+}
+
+// Store persists State per target key.
+type Store interface {
+	GetState(target string) (State, error)
+	SetState(target string, state State) error
+	Close() error
+}
+
+// MemoryStore is the zero-config default: it satisfies Store but does not
+// survive a restart, matching the monitor's previous in-memory behavior.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	states map[string]State
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: make(map[string]State)}
+}
+
+func (s *MemoryStore) GetState(target string) (State, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.states[target], nil
+}
+
+func (s *MemoryStore) SetState(target string, state State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[target] = state
+	return nil
+}
+
+func (s *MemoryStore) Close() error { return nil }
@@ -56,6 +56,45 @@ func NewClient(config Config, httpClient *http.Client) *Client {
 	}
 }
 
+// TokenSnapshot is the exported, persistable form of the client's cached
+// token, so a caller can save it between restarts.
+type TokenSnapshot struct {
+	AccessToken  string
+	RefreshToken string
+	ExpireTime   time.Time
+}
+
+// LoadToken seeds the client's in-memory token cache, e.g. from a
+// persistent store, so a restart doesn't force a fresh /v1.0/token call.
+func (c *Client) LoadToken(snap TokenSnapshot) {
+	if snap.AccessToken == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = &tokenInfo{
+		AccessToken:  snap.AccessToken,
+		RefreshToken: snap.RefreshToken,
+		ExpireTime:   snap.ExpireTime,
+	}
+}
+
+// SnapshotToken returns the client's current cached token for persistence.
+// ok is false if no token has been fetched yet.
+func (c *Client) SnapshotToken() (snap TokenSnapshot, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.token == nil {
+		return TokenSnapshot{}, false
+	}
+	return TokenSnapshot{
+		AccessToken:  c.token.AccessToken,
+		RefreshToken: c.token.RefreshToken,
+		ExpireTime:   c.token.ExpireTime,
+	}, true
+}
+
 func (c *Client) RestartDevice() error {
 	if !c.config.Enabled {
 		return nil